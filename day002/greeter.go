@@ -0,0 +1,44 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// GreetLogger greets people like Hello and remembers every greeting it has
+// produced. It is safe for concurrent use.
+type GreetLogger struct {
+	mu      sync.Mutex
+	history []string
+}
+
+func (l *GreetLogger) Greet(name string) string {
+	greeting := Hello(name, english)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.history = append(l.history, greeting)
+
+	return greeting
+}
+
+func (l *GreetLogger) History() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.history...)
+}
+
+// Greeter greets people like Hello and counts how many greetings it has
+// produced. It is safe for concurrent use.
+type Greeter struct {
+	count atomic.Int64
+}
+
+func (g *Greeter) Greet(name string) string {
+	g.count.Add(1)
+	return Hello(name, english)
+}
+
+func (g *Greeter) Count() int {
+	return int(g.count.Load())
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestGreetLogger(t *testing.T) {
+	t.Run("records greetings in order", func(t *testing.T) {
+		logger := &GreetLogger{}
+
+		AssertCorrectMessage(t, logger.Greet("Yassine"), "Hello, Yassine")
+		logger.Greet("")
+		logger.Greet("Sam")
+
+		got := logger.History()
+		want := []string{"Hello, Yassine", "Hello, Golang", "Hello, Sam"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("history is a copy", func(t *testing.T) {
+		logger := &GreetLogger{}
+		logger.Greet("Yassine")
+
+		logger.History()[0] = "tampered"
+
+		AssertCorrectMessage(t, logger.History()[0], "Hello, Yassine")
+	})
+
+	t.Run("safe for concurrent use", func(t *testing.T) {
+		logger := &GreetLogger{}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				logger.Greet("Gopher")
+			}()
+		}
+		wg.Wait()
+
+		if got := len(logger.History()); got != 50 {
+			t.Errorf("got %d greetings in the history want 50", got)
+		}
+	})
+}
+
+func TestGreeter(t *testing.T) {
+	t.Run("counts greetings", func(t *testing.T) {
+		greeter := &Greeter{}
+
+		AssertCorrectMessage(t, greeter.Greet("Yassine"), "Hello, Yassine")
+		greeter.Greet("")
+
+		if got := greeter.Count(); got != 2 {
+			t.Errorf("got count %d want 2", got)
+		}
+	})
+
+	t.Run("counts correctly under concurrent use", func(t *testing.T) {
+		greeter := &Greeter{}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 1000; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				greeter.Greet("Gopher")
+			}()
+		}
+		wg.Wait()
+
+		if got := greeter.Count(); got != 1000 {
+			t.Errorf("got count %d want 1000", got)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHelloHandler(t *testing.T) {
+	t.Run("greets the name from the query string", func(t *testing.T) {
+		response := serveHello(t, "/?name=Yassine")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertContentType(t, response, "text/plain; charset=utf-8")
+		AssertCorrectMessage(t, response.Body.String(), "Hello, Yassine")
+	})
+
+	t.Run("greets the default name when none is given", func(t *testing.T) {
+		response := serveHello(t, "/")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		AssertCorrectMessage(t, response.Body.String(), "Hello, Golang")
+	})
+}
+
+func serveHello(t testing.TB, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	response := httptest.NewRecorder()
+	HelloHandler(response, request)
+	return response
+}
+
+func assertStatus(t testing.TB, got, want int) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got status %d want %d", got, want)
+	}
+}
+
+func assertContentType(t testing.TB, response *httptest.ResponseRecorder, want string) {
+	t.Helper()
+	if got := response.Header().Get("Content-Type"); got != want {
+		t.Errorf("got content type %q want %q", got, want)
+	}
+}
@@ -1,14 +1,329 @@
 package main
 
-import "fmt"
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode"
+)
 
-func Hello(name string) string {
+const (
+	english = "English"
+	french  = "French"
+	spanish = "Spanish"
+	arabic  = "Arabic"
+
+	defaultName       = "Golang"
+	defaultGreeting   = "Hello"
+	greetingSeparator = ", "
+)
+
+var ErrInvalidName = errors.New("invalid name")
+
+var fallbackName = struct {
+	mu   sync.RWMutex
+	name string
+}{name: defaultName}
+
+var greetingPrefixes = struct {
+	mu       sync.RWMutex
+	prefixes map[string]string
+}{prefixes: map[string]string{
+	english: defaultGreeting,
+	french:  "Bonjour",
+	spanish: "Hola",
+	arabic:  "مرحبا",
+}}
+
+func Hello(name, language string) string {
+	return GreetWith(greetingPrefix(language), name)
+}
+
+func GreetWith(prefix, name string) string {
+	return greet(prefix, greetingSeparator, name)
+}
+
+func GreetSep(name, sep string) string {
+	return greet(defaultGreeting, sep, name)
+}
+
+func greet(prefix, sep, name string) string {
+	if prefix == "" {
+		prefix = defaultGreeting
+	}
+	return prefix + sep + nameOrDefault(name)
+}
+
+func GreetByTime(name string, t time.Time) string {
+	var greeting string
+	switch hour := t.Hour(); {
+	case hour < 12:
+		greeting = "Good morning"
+	case hour < 18:
+		greeting = "Good afternoon"
+	default:
+		greeting = "Good evening"
+	}
+	return GreetWith(greeting, name)
+}
+
+func HelloAll(names []string) string {
+	return Hello(joinNames(nonEmpty(names)), english)
+}
+
+// HelloGroup greets names like HelloAll, except that when groupForm is set
+// and there is more than one person it greets them all as "everyone".
+func HelloGroup(names []string, groupForm bool) string {
+	if present := nonEmpty(names); groupForm && len(present) > 1 {
+		return defaultGreeting + " everyone"
+	}
+	return HelloAll(names)
+}
+
+func nonEmpty(names []string) []string {
+	var present []string
+	for _, name := range names {
+		if name != "" {
+			present = append(present, name)
+		}
+	}
+	return present
+}
+
+func joinNames(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " and " + names[1]
+	default:
+		last := len(names) - 1
+		return strings.Join(names[:last], ", ") + ", and " + names[last]
+	}
+}
+
+// HelloFrom greets the name on the first line of r. An empty reader greets
+// the default name.
+func HelloFrom(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return Hello(strings.TrimRight(line, "\r\n"), english), nil
+}
+
+// GreetFile writes a greeting to w for each line of the file at path, with
+// blank lines greeting the default name. If reading fails part way through,
+// the greetings for the lines already read are written before the error is
+// returned.
+func GreetFile(path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return greetLines(f, w)
+}
+
+func greetLines(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintln(w, Hello(strings.TrimSpace(scanner.Text()), english)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func HelloChecked(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	for _, r := range name {
+		switch {
+		case unicode.IsControl(r):
+			return "", fmt.Errorf("%w: %q contains a control character", ErrInvalidName, name)
+		case unicode.IsDigit(r):
+			return "", fmt.Errorf("%w: %q contains a digit", ErrInvalidName, name)
+		}
+	}
+	return Hello(name, english), nil
+}
+
+// HelloClean greets name after trimming it and collapsing every run of
+// whitespace inside it, tabs and newlines included, to a single space.
+func HelloClean(name string) string {
+	return Hello(strings.Join(strings.Fields(name), " "), english)
+}
+
+func HelloNormalized(name string) string {
+	return Hello(normalizeName(name), english)
+}
+
+func normalizeName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		words[i] = titleCase(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// titleCase upper-cases the first letter of every letter run in word and
+// lower-cases the rest, so "jean-luc" becomes "Jean-Luc".
+func titleCase(word string) string {
+	runes := []rune(word)
+	startOfRun := true
+	for i, r := range runes {
+		if !unicode.IsLetter(r) {
+			startOfRun = true
+			continue
+		}
+		if startOfRun {
+			runes[i] = unicode.ToTitle(r)
+		} else {
+			runes[i] = unicode.ToLower(r)
+		}
+		startOfRun = false
+	}
+	return string(runes)
+}
+
+// HelloTruncated greets name like Hello, but cuts names longer than maxRunes
+// runes down to their first maxRunes runes followed by "…". A maxRunes of
+// zero or less means no limit.
+func HelloTruncated(name string, maxRunes int) string {
+	if runes := []rune(name); maxRunes > 0 && len(runes) > maxRunes {
+		name = string(runes[:maxRunes]) + "…"
+	}
+	return Hello(name, english)
+}
+
+// HelloOrDefault greets the first of names that isn't blank, so callers can
+// pass candidates in order of preference. With no usable name it greets the
+// default name.
+func HelloOrDefault(names ...string) string {
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			return Hello(name, english)
+		}
+	}
+	return Hello("", english)
+}
+
+// HelloDecorated greets name like Hello followed by a single space and
+// emoji, so HelloDecorated("Yassine", "👋") is "Hello, Yassine 👋". An empty
+// emoji gives the plain greeting.
+func HelloDecorated(name, emoji string) string {
+	greeting := Hello(name, english)
+	if emoji = strings.TrimSpace(emoji); emoji == "" {
+		return greeting
+	}
+	return greeting + " " + emoji
+}
+
+// HelloWithHook greets name like Hello and, when hook isn't nil, passes the
+// greeting to hook before returning it.
+func HelloWithHook(name string, hook func(greeting string)) string {
+	greeting := Hello(name, english)
+	if hook != nil {
+		hook(greeting)
+	}
+	return greeting
+}
+
+// GreetVisit greets name like Hello on a first visit and welcomes them back,
+// with the visit number, on every later one. A visitCount of zero or less
+// counts as a first visit.
+func GreetVisit(name string, visitCount int) string {
+	if visitCount <= 1 {
+		return Hello(name, english)
+	}
+	return fmt.Sprintf("%s (visit #%d)", GreetWith("Welcome back", name), visitCount)
+}
+
+// HelloTemplate renders tmpl, a text/template, with .Name set to name (or
+// the default name when it is empty) and .Default set to the default name.
+func HelloTemplate(name, tmpl string) (string, error) {
+	t, err := template.New("greeting").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var greeting strings.Builder
+	data := struct{ Name, Default string }{nameOrDefault(name), DefaultName()}
+	if err := t.Execute(&greeting, data); err != nil {
+		return "", err
+	}
+	return greeting.String(), nil
+}
+
+// GreetTitled greets name with a title such as "Dr." in front. An empty
+// title greets name just like Hello.
+func GreetTitled(title, name string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return Hello(name, english)
+	}
+	return Hello(title+" "+nameOrDefault(strings.TrimSpace(name)), english)
+}
+
+// SetDefaultName changes the name greeted when none is given. An empty name
+// restores the original "Golang" default.
+func SetDefaultName(name string) {
 	if name == "" {
-		name = "Golang"
+		name = defaultName
 	}
-	return "Hello, " + name
+	fallbackName.mu.Lock()
+	defer fallbackName.mu.Unlock()
+	fallbackName.name = name
+}
+
+func DefaultName() string {
+	fallbackName.mu.RLock()
+	defer fallbackName.mu.RUnlock()
+	return fallbackName.name
+}
+
+func nameOrDefault(name string) string {
+	if name == "" {
+		return DefaultName()
+	}
+	return name
+}
+
+// RegisterLanguage lets Hello greet in language using prefix, replacing the
+// prefix of a language that is already known.
+func RegisterLanguage(language, prefix string) {
+	greetingPrefixes.mu.Lock()
+	defer greetingPrefixes.mu.Unlock()
+	greetingPrefixes.prefixes[language] = prefix
+}
+
+// Languages returns the names of every language Hello knows, sorted.
+func Languages() []string {
+	greetingPrefixes.mu.RLock()
+	defer greetingPrefixes.mu.RUnlock()
+	languages := make([]string, 0, len(greetingPrefixes.prefixes))
+	for language := range greetingPrefixes.prefixes {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+func greetingPrefix(language string) string {
+	greetingPrefixes.mu.RLock()
+	defer greetingPrefixes.mu.RUnlock()
+	return greetingPrefixes.prefixes[language]
 }
 
 func main() {
-	fmt.Println(Hello("Yassine"))
+	fmt.Println(Hello("Yassine", english))
 }
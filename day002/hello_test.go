@@ -1,18 +1,570 @@
 package main
 
-import "testing"
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
 
 func TestHello(t *testing.T) {
 	t.Run("Saying hello to people", func(t *testing.T) {
-		got := Hello("Yassine")
+		got := Hello("Yassine", "")
 		want := "Hello, Yassine"
 		AssertCorrectMessage(t, got, want)
 	})
 	t.Run("Say 'Hello, Golang' when an empty string is supplied", func(t *testing.T) {
-		got := Hello("")
+		got := Hello("", "")
 		want := "Hello, Golang"
 		AssertCorrectMessage(t, got, want)
 	})
+	t.Run("in English", func(t *testing.T) {
+		got := Hello("Yassine", "English")
+		want := "Hello, Yassine"
+		AssertCorrectMessage(t, got, want)
+	})
+	t.Run("in French", func(t *testing.T) {
+		got := Hello("Yassine", "French")
+		want := "Bonjour, Yassine"
+		AssertCorrectMessage(t, got, want)
+	})
+	t.Run("in Spanish", func(t *testing.T) {
+		got := Hello("Yassine", "Spanish")
+		want := "Hola, Yassine"
+		AssertCorrectMessage(t, got, want)
+	})
+	t.Run("in Arabic", func(t *testing.T) {
+		got := Hello("Yassine", "Arabic")
+		want := "مرحبا, Yassine"
+		AssertCorrectMessage(t, got, want)
+	})
+	t.Run("default name is greeted in the requested language", func(t *testing.T) {
+		got := Hello("", "French")
+		want := "Bonjour, Golang"
+		AssertCorrectMessage(t, got, want)
+	})
+	t.Run("unknown language falls back to English", func(t *testing.T) {
+		got := Hello("Yassine", "Klingon")
+		want := "Hello, Yassine"
+		AssertCorrectMessage(t, got, want)
+	})
+}
+
+func TestGreetWith(t *testing.T) {
+	greetWithTests := []struct {
+		name         string
+		prefix, whom string
+		want         string
+	}{
+		{"custom prefix", "Welcome", "Yassine", "Welcome, Yassine"},
+		{"empty prefix", "", "Yassine", "Hello, Yassine"},
+		{"empty name", "Welcome", "", "Welcome, Golang"},
+		{"both empty", "", "", "Hello, Golang"},
+	}
+
+	for _, tt := range greetWithTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GreetWith(tt.prefix, tt.whom)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestGreetSep(t *testing.T) {
+	greetSepTests := []struct {
+		name      string
+		whom, sep string
+		want      string
+	}{
+		{"dash", "Yassine", " - ", "Hello - Yassine"},
+		{"space only", "Yassine", " ", "Hello Yassine"},
+		{"empty separator", "Yassine", "", "HelloYassine"},
+		{"default name", "", ": ", "Hello: Golang"},
+	}
+
+	for _, tt := range greetSepTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GreetSep(tt.whom, tt.sep)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestGreetByTime(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.January, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	greetingTests := []struct {
+		name string
+		when time.Time
+		want string
+	}{
+		{"early morning", at(6, 30), "Good morning, Yassine"},
+		{"just before noon", at(11, 59), "Good morning, Yassine"},
+		{"exactly noon", at(12, 0), "Good afternoon, Yassine"},
+		{"just before six", at(17, 59), "Good afternoon, Yassine"},
+		{"exactly six", at(18, 0), "Good evening, Yassine"},
+		{"late night", at(23, 0), "Good evening, Yassine"},
+	}
+
+	for _, tt := range greetingTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GreetByTime("Yassine", tt.when)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+
+	t.Run("empty name uses the default", func(t *testing.T) {
+		got := GreetByTime("", at(9, 0))
+		want := "Good morning, Golang"
+		AssertCorrectMessage(t, got, want)
+	})
+}
+
+func TestHelloAll(t *testing.T) {
+	helloAllTests := []struct {
+		name  string
+		names []string
+		want  string
+	}{
+		{"one name", []string{"Yassine"}, "Hello, Yassine"},
+		{"two names", []string{"Yassine", "Sam"}, "Hello, Yassine and Sam"},
+		{"three names", []string{"A", "B", "C"}, "Hello, A, B, and C"},
+		{"four names", []string{"A", "B", "C", "D"}, "Hello, A, B, C, and D"},
+		{"empty entries are skipped", []string{"", "Yassine", "", "Sam"}, "Hello, Yassine and Sam"},
+		{"empty slice", []string{}, "Hello, Golang"},
+		{"only empty entries", []string{"", ""}, "Hello, Golang"},
+	}
+
+	for _, tt := range helloAllTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloAll(tt.names)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloGroup(t *testing.T) {
+	helloGroupTests := []struct {
+		name      string
+		names     []string
+		groupForm bool
+		want      string
+	}{
+		{"single name", []string{"Yassine"}, false, "Hello, Yassine"},
+		{"single name in group form", []string{"Yassine"}, true, "Hello, Yassine"},
+		{"several names", []string{"Yassine", "Sam"}, false, "Hello, Yassine and Sam"},
+		{"several names in group form", []string{"Yassine", "Sam", "Ana"}, true, "Hello everyone"},
+		{"only one real name in group form", []string{"", "Sam"}, true, "Hello, Sam"},
+		{"empty slice in group form", nil, true, "Hello, Golang"},
+	}
+
+	for _, tt := range helloGroupTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloGroup(tt.names, tt.groupForm)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloFrom(t *testing.T) {
+	t.Run("reads the name from the first line", func(t *testing.T) {
+		got, err := HelloFrom(strings.NewReader("Yassine\nSam\n"))
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hello, Yassine")
+	})
+	t.Run("windows line endings are trimmed", func(t *testing.T) {
+		got, err := HelloFrom(strings.NewReader("Yassine\r\n"))
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hello, Yassine")
+	})
+	t.Run("empty input uses the default", func(t *testing.T) {
+		got, err := HelloFrom(strings.NewReader(""))
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hello, Golang")
+	})
+	t.Run("read errors are returned", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		_, err := HelloFrom(iotest.ErrReader(readErr))
+		if err != readErr {
+			t.Errorf("got error %v want %v", err, readErr)
+		}
+	})
+}
+
+func TestGreetFile(t *testing.T) {
+	t.Run("greets every line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "names.txt")
+		if err := os.WriteFile(path, []byte("Yassine\n\nSam\r\n   \nAlex"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var out strings.Builder
+		err := GreetFile(path, &out)
+		assertNoError(t, err)
+		AssertCorrectMessage(t, out.String(), "Hello, Yassine\nHello, Golang\nHello, Sam\nHello, Golang\nHello, Alex\n")
+	})
+	t.Run("missing files are reported", func(t *testing.T) {
+		err := GreetFile(filepath.Join(t.TempDir(), "missing.txt"), io.Discard)
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("got error %v want %v", err, fs.ErrNotExist)
+		}
+	})
+	t.Run("read errors come after the lines already read", func(t *testing.T) {
+		readErr := errors.New("disk on fire")
+		var out strings.Builder
+		err := greetLines(io.MultiReader(strings.NewReader("Yassine\nSam\n"), iotest.ErrReader(readErr)), &out)
+		if err != readErr {
+			t.Errorf("got error %v want %v", err, readErr)
+		}
+		AssertCorrectMessage(t, out.String(), "Hello, Yassine\nHello, Sam\n")
+	})
+}
+
+func TestHelloChecked(t *testing.T) {
+	t.Run("valid name", func(t *testing.T) {
+		got, err := HelloChecked("  Yassine  ")
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hello, Yassine")
+	})
+	t.Run("whitespace only uses the default", func(t *testing.T) {
+		got, err := HelloChecked(" \t ")
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hello, Golang")
+	})
+	t.Run("name with a digit", func(t *testing.T) {
+		_, err := HelloChecked("Yass1ne")
+		assertInvalidName(t, err)
+	})
+	t.Run("name with a control character", func(t *testing.T) {
+		_, err := HelloChecked("Yas\x00sine")
+		assertInvalidName(t, err)
+	})
+}
+
+func TestHelloClean(t *testing.T) {
+	cleanTests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"double spaces", "John   Smith", "Hello, John Smith"},
+		{"tabs", "John\t\tSmith", "Hello, John Smith"},
+		{"newlines", "John\nSmith", "Hello, John Smith"},
+		{"a mix", " \tJohn \t\n Paul\r\n  Smith \n", "Hello, John Paul Smith"},
+		{"already clean", "John Smith", "Hello, John Smith"},
+		{"only whitespace", " \t\n ", "Hello, Golang"},
+	}
+
+	for _, tt := range cleanTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloClean(tt.input)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloNormalized(t *testing.T) {
+	normalizedTests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"leading and trailing spaces", "  yassine  ", "Hello, Yassine"},
+		{"all caps", "YASSINE", "Hello, Yassine"},
+		{"mixed case", "yASSINE", "Hello, Yassine"},
+		{"several words", "john smith", "Hello, John Smith"},
+		{"accented", "élodie", "Hello, Élodie"},
+		{"hyphenated", "jean-luc", "Hello, Jean-Luc"},
+		{"empty", "   ", "Hello, Golang"},
+	}
+
+	for _, tt := range normalizedTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloNormalized(tt.input)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloTruncated(t *testing.T) {
+	truncatedTests := []struct {
+		name     string
+		input    string
+		maxRunes int
+		want     string
+	}{
+		{"short name", "Sam", 5, "Hello, Sam"},
+		{"exactly the limit", "Yassine", 7, "Hello, Yassine"},
+		{"long name", "Bartholomew", 4, "Hello, Bart…"},
+		{"long multibyte name", "Zoë Ångström", 5, "Hello, Zoë Å…"},
+		{"long arabic name", "ياسين", 2, "Hello, يا…"},
+		{"no limit", "Bartholomew", 0, "Hello, Bartholomew"},
+		{"negative limit", "Bartholomew", -1, "Hello, Bartholomew"},
+		{"empty name", "", 3, "Hello, Golang"},
+	}
+
+	for _, tt := range truncatedTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloTruncated(tt.input, tt.maxRunes)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloOrDefault(t *testing.T) {
+	orDefaultTests := []struct {
+		name  string
+		names []string
+		want  string
+	}{
+		{"first populated", []string{"Yassine", "yassine@example.com", "ysebri"}, "Hello, Yassine"},
+		{"later populated", []string{"", "  ", "ysebri"}, "Hello, ysebri"},
+		{"surrounding spaces are trimmed", []string{"\t", " Sam "}, "Hello, Sam"},
+		{"all empty", []string{"", " ", "\n"}, "Hello, Golang"},
+		{"no arguments", nil, "Hello, Golang"},
+	}
+
+	for _, tt := range orDefaultTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloOrDefault(tt.names...)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloDecorated(t *testing.T) {
+	decoratedTests := []struct {
+		name  string
+		input string
+		emoji string
+		want  string
+	}{
+		{"with an emoji", "Yassine", "👋", "Hello, Yassine 👋"},
+		{"with a multi-rune emoji", "Sam", "👋🏽", "Hello, Sam 👋🏽"},
+		{"with a text decoration", "Sam", "!!", "Hello, Sam !!"},
+		{"padded emoji is not double-spaced", "Sam", " 🎉 ", "Hello, Sam 🎉"},
+		{"without an emoji", "Yassine", "", "Hello, Yassine"},
+		{"blank emoji", "Yassine", "  ", "Hello, Yassine"},
+	}
+
+	for _, tt := range decoratedTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HelloDecorated(tt.input, tt.emoji)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloWithHook(t *testing.T) {
+	t.Run("the hook sees the returned greeting", func(t *testing.T) {
+		var hooked []string
+		got := HelloWithHook("Yassine", func(greeting string) {
+			hooked = append(hooked, greeting)
+		})
+
+		AssertCorrectMessage(t, got, "Hello, Yassine")
+		if len(hooked) != 1 {
+			t.Fatalf("got %d hook calls want 1", len(hooked))
+		}
+		AssertCorrectMessage(t, hooked[0], got)
+	})
+	t.Run("a nil hook is ignored", func(t *testing.T) {
+		AssertCorrectMessage(t, HelloWithHook("", nil), "Hello, Golang")
+	})
+}
+
+func TestGreetVisit(t *testing.T) {
+	visitTests := []struct {
+		name       string
+		input      string
+		visitCount int
+		want       string
+	}{
+		{"first visit", "Yassine", 1, "Hello, Yassine"},
+		{"second visit", "Yassine", 2, "Welcome back, Yassine (visit #2)"},
+		{"many visits", "Sam", 42, "Welcome back, Sam (visit #42)"},
+		{"zero counts as first", "Sam", 0, "Hello, Sam"},
+		{"negative counts as first", "Sam", -3, "Hello, Sam"},
+		{"default name", "", 3, "Welcome back, Golang (visit #3)"},
+	}
+
+	for _, tt := range visitTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GreetVisit(tt.input, tt.visitCount)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestHelloTemplate(t *testing.T) {
+	t.Run("renders a custom template", func(t *testing.T) {
+		got, err := HelloTemplate("Yassine", "Hey {{.Name}}, welcome aboard!")
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hey Yassine, welcome aboard!")
+	})
+	t.Run("an empty name uses the default", func(t *testing.T) {
+		got, err := HelloTemplate("", "Hi {{.Name}}")
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Hi Golang")
+	})
+	t.Run("the default name is available", func(t *testing.T) {
+		got, err := HelloTemplate("Sam", "{{.Name}} is not {{.Default}}")
+		assertNoError(t, err)
+		AssertCorrectMessage(t, got, "Sam is not Golang")
+	})
+	t.Run("malformed templates are an error", func(t *testing.T) {
+		if _, err := HelloTemplate("Sam", "Hi {{.Name"); err == nil {
+			t.Error("expected a parse error")
+		}
+	})
+	t.Run("unknown fields are an error", func(t *testing.T) {
+		if _, err := HelloTemplate("Sam", "Hi {{.Surname}}"); err == nil {
+			t.Error("expected an execution error")
+		}
+	})
+}
+
+func TestGreetTitled(t *testing.T) {
+	titledTests := []struct {
+		name  string
+		title string
+		input string
+		want  string
+	}{
+		{"with a title", "Dr.", "Smith", "Hello, Dr. Smith"},
+		{"empty title", "", "Smith", "Hello, Smith"},
+		{"title with trailing space", "Dr. ", "Smith", "Hello, Dr. Smith"},
+		{"padded title and name", "  Prof.  ", " Smith", "Hello, Prof. Smith"},
+		{"empty name", "Dr.", "", "Hello, Dr. Golang"},
+	}
+
+	for _, tt := range titledTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GreetTitled(tt.title, tt.input)
+			AssertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	t.Cleanup(func() {
+		for _, language := range []string{"Italian", "German", "Portuguese"} {
+			unregisterLanguage(language)
+		}
+	})
+
+	t.Run("greets in a registered language", func(t *testing.T) {
+		RegisterLanguage("Italian", "Ciao")
+
+		AssertCorrectMessage(t, Hello("Yassine", "Italian"), "Ciao, Yassine")
+	})
+	t.Run("registering again replaces the prefix", func(t *testing.T) {
+		RegisterLanguage("German", "Hallo")
+		RegisterLanguage("German", "Guten Tag")
+
+		AssertCorrectMessage(t, Hello("Sam", "German"), "Guten Tag, Sam")
+	})
+	t.Run("lists every language", func(t *testing.T) {
+		RegisterLanguage("Italian", "Ciao")
+
+		got := Languages()
+		for _, want := range []string{arabic, english, french, "Italian", spanish} {
+			if i := sort.SearchStrings(got, want); i == len(got) || got[i] != want {
+				t.Errorf("got languages %q, want them to include %q", got, want)
+			}
+		}
+		if !sort.StringsAreSorted(got) {
+			t.Errorf("got languages %q, want them sorted", got)
+		}
+	})
+	t.Run("safe for concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				RegisterLanguage("Portuguese", "Olá")
+				Hello("Gopher", "Portuguese")
+				Languages()
+			}()
+		}
+		wg.Wait()
+
+		AssertCorrectMessage(t, Hello("Gopher", "Portuguese"), "Olá, Gopher")
+	})
+	t.Run("unregistering removes the language", func(t *testing.T) {
+		RegisterLanguage("Dutch", "Hallo")
+		unregisterLanguage("Dutch")
+
+		AssertCorrectMessage(t, Hello("Sam", "Dutch"), "Hello, Sam")
+		got := Languages()
+		if i := sort.SearchStrings(got, "Dutch"); i < len(got) && got[i] == "Dutch" {
+			t.Errorf("got languages %q, want Dutch gone", got)
+		}
+	})
+}
+
+// unregisterLanguage undoes RegisterLanguage so tests don't leak languages
+// into the global registry.
+func unregisterLanguage(language string) {
+	greetingPrefixes.mu.Lock()
+	defer greetingPrefixes.mu.Unlock()
+	delete(greetingPrefixes.prefixes, language)
+}
+
+func TestDefaultName(t *testing.T) {
+	t.Cleanup(func() { SetDefaultName("") })
+
+	AssertCorrectMessage(t, DefaultName(), "Golang")
+
+	SetDefaultName("Gopher")
+	AssertCorrectMessage(t, DefaultName(), "Gopher")
+	AssertCorrectMessage(t, Hello("", ""), "Hello, Gopher")
+	AssertCorrectMessage(t, Hello("Yassine", ""), "Hello, Yassine")
+
+	SetDefaultName("")
+	AssertCorrectMessage(t, DefaultName(), "Golang")
+	AssertCorrectMessage(t, Hello("", ""), "Hello, Golang")
+}
+
+func TestDefaultNameConcurrently(t *testing.T) {
+	t.Cleanup(func() { SetDefaultName("") })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			SetDefaultName("Gopher")
+			Hello("", "")
+		}()
+	}
+	wg.Wait()
+
+	AssertCorrectMessage(t, DefaultName(), "Gopher")
+}
+
+func assertNoError(t testing.TB, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("got an error but didn't want one: %v", err)
+	}
+}
+
+func assertInvalidName(t testing.TB, err error) {
+	t.Helper()
+	if !errors.Is(err, ErrInvalidName) {
+		t.Errorf("got error %v want %v", err, ErrInvalidName)
+	}
 }
 
 func AssertCorrectMessage(t testing.TB, got string, want string) {
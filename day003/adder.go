@@ -1,5 +1,132 @@
 package adder
 
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+var (
+	ErrOverflow       = errors.New("integer overflow")
+	ErrZeroModulus    = errors.New("modulus must not be zero")
+	ErrLengthMismatch = errors.New("vectors must have the same length")
+	ErrDivideByZero   = errors.New("cannot divide by zero")
+)
+
+// Number mirrors constraints.Integer | constraints.Float from
+// golang.org/x/exp so the module keeps no external dependencies.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Add returns a + b with the usual signs: zero leaves the other operand
+// unchanged, and a negative operand subtracts its magnitude. Like Go's +
+// operator it wraps on overflow; use AddChecked to detect that.
 func Add(a, b int) int {
+	return AddMany(a, b)
+}
+
+func AddMany(numbers ...int) int {
+	sum := 0
+	for _, number := range numbers {
+		sum += number
+	}
+	return sum
+}
+
+func Subtract(a, b int) int {
+	return a - b
+}
+
+func Multiply(a, b int) int {
+	return a * b
+}
+
+// Divide returns a / b truncated towards zero, like Go's / operator.
+func Divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, ErrDivideByZero
+	}
+	return a / b, nil
+}
+
+func AddParsed(values ...string) (int, error) {
+	sum := 0
+	for i, value := range values {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("argument %d (%q) is not an integer: %w", i, value, err)
+		}
+		sum += number
+	}
+	return sum, nil
+}
+
+func AddChecked(a, b int) (int, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+func AddG[T Number](a, b T) T {
 	return a + b
 }
+
+// AddBig returns a new big.Int holding a+b. Nil operands count as zero and
+// neither operand is modified.
+func AddBig(a, b *big.Int) *big.Int {
+	sum := new(big.Int)
+	if a != nil {
+		sum.Set(a)
+	}
+	if b != nil {
+		sum.Add(sum, b)
+	}
+	return sum
+}
+
+// AddMod returns the Euclidean (a + b) mod m, which is always in [0, |m|).
+// The operands are reduced before they are added so the sum cannot overflow.
+func AddMod(a, b, m int) (int, error) {
+	if m == 0 {
+		return 0, ErrZeroModulus
+	}
+	modulus := absUint(m)
+	sum := euclidMod(a, modulus) + euclidMod(b, modulus)
+	return int(sum % modulus), nil
+}
+
+func euclidMod(n int, m uint) uint {
+	if n >= 0 {
+		return uint(n) % m
+	}
+	r := absUint(n) % m
+	if r == 0 {
+		return 0
+	}
+	return m - r
+}
+
+// absUint returns |n| as a uint, which unlike int can hold |math.MinInt|.
+func absUint(n int) uint {
+	if n >= 0 {
+		return uint(n)
+	}
+	return uint(-(n + 1)) + 1
+}
+
+func AddVectors(a, b []int) ([]int, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("%w: got %d and %d", ErrLengthMismatch, len(a), len(b))
+	}
+	sums := make([]int, len(a))
+	for i := range a {
+		sums[i] = a[i] + b[i]
+	}
+	return sums, nil
+}
@@ -1,6 +1,14 @@
 package adder
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestAdder(t *testing.T) {
 	got := Add(2, 2)
@@ -9,3 +17,339 @@ func TestAdder(t *testing.T) {
 		t.Errorf("Expected '%d' but got '%d'", want, got)
 	}
 }
+
+func TestAddSigns(t *testing.T) {
+	signTests := []struct {
+		name string
+		a, b int
+		want int
+	}{
+		{"both zero", 0, 0, 0},
+		{"zero on the left", 0, 5, 5},
+		{"zero on the right", -5, 0, -5},
+		{"both negative", -2, -3, -5},
+		{"negative and larger positive", -2, 7, 5},
+		{"positive and larger negative", 2, -7, -5},
+		{"opposites cancel", 9, -9, 0},
+	}
+
+	for _, tt := range signTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Add(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("Expected '%d' but got '%d'", tt.want, got)
+			}
+			if reversed := Add(tt.b, tt.a); reversed != got {
+				t.Errorf("Expected Add to be commutative but got '%d' and '%d'", got, reversed)
+			}
+		})
+	}
+}
+
+func Example_add() {
+	fmt.Println(Add(2, 2))
+	fmt.Println(Add(-2, -3))
+	fmt.Println(Add(-2, 7))
+	fmt.Println(Add(0, -4))
+	// Output:
+	// 4
+	// -5
+	// 5
+	// -4
+}
+
+func TestAddMany(t *testing.T) {
+	addManyTests := []struct {
+		name    string
+		numbers []int
+		want    int
+	}{
+		{"no arguments", nil, 0},
+		{"one argument", []int{7}, 7},
+		{"several arguments", []int{1, 2, 3, 4}, 10},
+	}
+
+	for _, tt := range addManyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AddMany(tt.numbers...)
+			if got != tt.want {
+				t.Errorf("Expected '%d' but got '%d'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	arithmeticTests := []struct {
+		name string
+		op   func(a, b int) int
+		a, b int
+		want int
+	}{
+		{"subtract", Subtract, 5, 3, 2},
+		{"subtract into negatives", Subtract, 3, 5, -2},
+		{"subtract a negative", Subtract, 3, -5, 8},
+		{"multiply", Multiply, 4, 3, 12},
+		{"multiply a negative", Multiply, -4, 3, -12},
+		{"multiply two negatives", Multiply, -4, -3, 12},
+		{"multiply by zero", Multiply, -4, 0, 0},
+	}
+
+	for _, tt := range arithmeticTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("Expected '%d' but got '%d'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDivide(t *testing.T) {
+	divideTests := []struct {
+		name string
+		a, b int
+		want int
+	}{
+		{"exact division", 12, 3, 4},
+		{"truncates towards zero", 7, 2, 3},
+		{"negative dividend", -7, 2, -3},
+		{"negative divisor", 7, -2, -3},
+		{"zero dividend", 0, 5, 0},
+	}
+
+	for _, tt := range divideTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Divide(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected '%d' but got '%d'", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("divide by zero", func(t *testing.T) {
+		_, err := Divide(1, 0)
+		if err != ErrDivideByZero {
+			t.Errorf("got error %v want %v", err, ErrDivideByZero)
+		}
+	})
+}
+
+func TestAddParsed(t *testing.T) {
+	t.Run("all valid", func(t *testing.T) {
+		got, err := AddParsed("1", "-2", "30")
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got != 29 {
+			t.Errorf("Expected '%d' but got '%d'", 29, got)
+		}
+	})
+
+	t.Run("no arguments", func(t *testing.T) {
+		got, err := AddParsed()
+		if err != nil || got != 0 {
+			t.Errorf("Expected 0, nil but got %d, %v", got, err)
+		}
+	})
+
+	t.Run("invalid argument in the middle", func(t *testing.T) {
+		_, err := AddParsed("1", "2x", "3")
+		if err == nil {
+			t.Fatal("expected an error but didn't get one")
+		}
+		want := `argument 1 ("2x") is not an integer`
+		if !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("got error %q want it to start with %q", err, want)
+		}
+	})
+}
+
+func TestAddChecked(t *testing.T) {
+	t.Run("returns the sum when it fits", func(t *testing.T) {
+		got, err := AddChecked(math.MaxInt-1, 1)
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got != math.MaxInt {
+			t.Errorf("Expected '%d' but got '%d'", math.MaxInt, got)
+		}
+	})
+
+	overflowTests := []struct {
+		name string
+		a, b int
+	}{
+		{"positive overflow", math.MaxInt, 1},
+		{"negative overflow", math.MinInt, -1},
+		{"both extremes", math.MinInt, math.MinInt},
+	}
+
+	for _, tt := range overflowTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AddChecked(tt.a, tt.b)
+			if err != ErrOverflow {
+				t.Errorf("got error %v want %v", err, ErrOverflow)
+			}
+		})
+	}
+
+	t.Run("opposite signs never overflow", func(t *testing.T) {
+		got, err := AddChecked(math.MaxInt, math.MinInt)
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got != -1 {
+			t.Errorf("Expected '%d' but got '%d'", -1, got)
+		}
+	})
+}
+
+func TestAddG(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		got := AddG(2, 3)
+		if got != 5 {
+			t.Errorf("Expected '%d' but got '%d'", 5, got)
+		}
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		got := AddG[int64](math.MaxInt32, math.MaxInt32)
+		want := int64(2 * math.MaxInt32)
+		if got != want {
+			t.Errorf("Expected '%d' but got '%d'", want, got)
+		}
+	})
+
+	t.Run("uint", func(t *testing.T) {
+		got := AddG[uint](1, 2)
+		if got != 3 {
+			t.Errorf("Expected '%d' but got '%d'", 3, got)
+		}
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		const epsilon = 1e-9
+		got := AddG(0.1, 0.2)
+		want := 0.3
+		if math.Abs(got-want) > epsilon {
+			t.Errorf("Expected '%g' but got '%g'", want, got)
+		}
+	})
+}
+
+func TestAddBig(t *testing.T) {
+	mustParse := func(t testing.TB, s string) *big.Int {
+		t.Helper()
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			t.Fatalf("could not parse %q", s)
+		}
+		return n
+	}
+
+	t.Run("adds values beyond int64 without truncation", func(t *testing.T) {
+		a := mustParse(t, "123456789012345678901234567890")
+		b := mustParse(t, "987654321098765432109876543210")
+		want := mustParse(t, "1111111110111111111011111111100")
+
+		got := AddBig(a, b)
+		if got.Cmp(want) != 0 {
+			t.Errorf("Expected '%s' but got '%s'", want, got)
+		}
+	})
+
+	t.Run("does not mutate its inputs", func(t *testing.T) {
+		a := big.NewInt(math.MaxInt64)
+		b := big.NewInt(math.MaxInt64)
+
+		AddBig(a, b)
+
+		if a.Int64() != math.MaxInt64 || b.Int64() != math.MaxInt64 {
+			t.Errorf("inputs were modified: a=%s b=%s", a, b)
+		}
+	})
+
+	t.Run("treats nil as zero", func(t *testing.T) {
+		a := big.NewInt(42)
+
+		if got := AddBig(a, nil); got.Cmp(a) != 0 {
+			t.Errorf("Expected '%s' but got '%s'", a, got)
+		}
+		if got := AddBig(nil, a); got.Cmp(a) != 0 {
+			t.Errorf("Expected '%s' but got '%s'", a, got)
+		}
+		if got := AddBig(nil, nil); got.Sign() != 0 {
+			t.Errorf("Expected '0' but got '%s'", got)
+		}
+	})
+}
+
+func TestAddMod(t *testing.T) {
+	addModTests := []struct {
+		name    string
+		a, b, m int
+		want    int
+	}{
+		{"small operands", 5, 4, 7, 2},
+		{"exact multiple", 3, 4, 7, 0},
+		{"negative operand", -5, 1, 7, 3},
+		{"both negative", -5, -6, 7, 3},
+		{"negative modulus", 5, 4, -7, 2},
+		{"no intermediate overflow", math.MaxInt, math.MaxInt, 10, 4},
+		{"most negative operands", math.MinInt, math.MinInt, 10, 4},
+		{"most negative modulus", math.MinInt + 1, 0, math.MinInt, 1},
+	}
+
+	for _, tt := range addModTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddMod(tt.a, tt.b, tt.m)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected '%d' but got '%d'", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("zero modulus", func(t *testing.T) {
+		_, err := AddMod(1, 2, 0)
+		if err != ErrZeroModulus {
+			t.Errorf("got error %v want %v", err, ErrZeroModulus)
+		}
+	})
+}
+
+func TestAddVectors(t *testing.T) {
+	t.Run("adds element-wise", func(t *testing.T) {
+		got, err := AddVectors([]int{1, 2, 3}, []int{10, -20, 30})
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		want := []int{11, -18, 33}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v but got %v", want, got)
+		}
+	})
+
+	t.Run("empty vectors give an empty, non-nil result", func(t *testing.T) {
+		got, err := AddVectors(nil, []int{})
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got == nil || len(got) != 0 {
+			t.Errorf("Expected an empty slice but got %#v", got)
+		}
+	})
+
+	t.Run("mismatched lengths", func(t *testing.T) {
+		_, err := AddVectors([]int{1, 2}, []int{1})
+		if !errors.Is(err, ErrLengthMismatch) {
+			t.Errorf("got error %v want %v", err, ErrLengthMismatch)
+		}
+	})
+}
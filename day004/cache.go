@@ -0,0 +1,66 @@
+package iteration
+
+import (
+	"container/list"
+	"sync"
+)
+
+type repeatKey struct {
+	s string
+	n int
+}
+
+type repeatEntry struct {
+	key   repeatKey
+	value string
+}
+
+// RepeatCache memoizes Repeat, keeping at most maxEntries results and
+// evicting the least recently used one when full. It is safe for concurrent
+// use.
+type RepeatCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[repeatKey]*list.Element
+}
+
+// NewRepeatCache returns a cache holding up to maxEntries results. A
+// maxEntries of zero or less disables caching.
+func NewRepeatCache(maxEntries int) *RepeatCache {
+	return &RepeatCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[repeatKey]*list.Element),
+	}
+}
+
+func (c *RepeatCache) Repeat(s string, n int) string {
+	key := repeatKey{s, n}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*repeatEntry).value
+	}
+
+	value := Repeat(s, n)
+	if c.maxEntries <= 0 {
+		return value
+	}
+	c.entries[key] = c.order.PushFront(&repeatEntry{key, value})
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*repeatEntry).key)
+	}
+	return value
+}
+
+func (c *RepeatCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,76 @@
+package iteration
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRepeatCache(t *testing.T) {
+	t.Run("Cache hits return the same result", func(t *testing.T) {
+		cache := NewRepeatCache(2)
+
+		first := cache.Repeat("ab", 3)
+		second := cache.Repeat("ab", 3)
+
+		assertCorrectMessage(t, first, "ababab")
+		assertCorrectMessage(t, second, first)
+		if cache.Len() != 1 {
+			t.Errorf("Expected 1 cached entry but got %d", cache.Len())
+		}
+	})
+
+	t.Run("Eviction respects the bound", func(t *testing.T) {
+		cache := NewRepeatCache(2)
+
+		cache.Repeat("a", 1)
+		cache.Repeat("b", 1)
+		cache.Repeat("c", 1)
+
+		if cache.Len() != 2 {
+			t.Errorf("Expected 2 cached entries but got %d", cache.Len())
+		}
+	})
+
+	t.Run("Least recently used entry is evicted first", func(t *testing.T) {
+		cache := NewRepeatCache(2)
+
+		cache.Repeat("a", 1)
+		cache.Repeat("b", 1)
+		cache.Repeat("a", 1)
+		cache.Repeat("c", 1)
+
+		if _, ok := cache.entries[repeatKey{"a", 1}]; !ok {
+			t.Error("Expected the recently used entry to be kept")
+		}
+		if _, ok := cache.entries[repeatKey{"b", 1}]; ok {
+			t.Error("Expected the least recently used entry to be evicted")
+		}
+	})
+
+	t.Run("Zero entries disables caching", func(t *testing.T) {
+		cache := NewRepeatCache(0)
+
+		assertCorrectMessage(t, cache.Repeat("x", 2), "xx")
+		if cache.Len() != 0 {
+			t.Errorf("Expected nothing cached but got %d entries", cache.Len())
+		}
+	})
+
+	t.Run("Safe for concurrent use", func(t *testing.T) {
+		cache := NewRepeatCache(3)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				assertCorrectMessage(t, cache.Repeat("z", n%5), Repeat("z", n%5))
+			}(i)
+		}
+		wg.Wait()
+
+		if cache.Len() > 3 {
+			t.Errorf("Expected at most 3 cached entries but got %d", cache.Len())
+		}
+	})
+}
@@ -1,9 +1,167 @@
 package iteration
 
-func Repeat(character string, repeatCount int) string {
-	repeated := ""
+import (
+	"io"
+	"math"
+	"strings"
+)
+
+// Repeat returns s repeated repeatCount times. s may be any string, including
+// multi-rune and multibyte ones. A repeatCount of zero or less, or an empty
+// s, yields an empty string. Like strings.Repeat, it panics if the result's
+// length would overflow an int.
+func Repeat(s string, repeatCount int) string {
+	if repeatCount <= 0 || s == "" {
+		return ""
+	}
+	if repeatCount > math.MaxInt/len(s) {
+		panic("iteration: Repeat output length overflow")
+	}
+	var repeated strings.Builder
+	repeated.Grow(len(s) * repeatCount)
+	for i := 0; i < repeatCount; i++ {
+		repeated.WriteString(s)
+	}
+	return repeated.String()
+}
+
+// RepeatWithSeparator returns repeatCount copies of s with sep between each
+// pair. Like Repeat, it panics if the result's length would overflow an int.
+func RepeatWithSeparator(s string, repeatCount int, sep string) string {
+	if repeatCount <= 0 {
+		return ""
+	}
+	if len(s) > 0 && repeatCount > math.MaxInt/len(s) ||
+		len(sep) > 0 && repeatCount-1 > (math.MaxInt-len(s)*repeatCount)/len(sep) {
+		panic("iteration: RepeatWithSeparator output length overflow")
+	}
+	var repeated strings.Builder
+	repeated.Grow(len(s)*repeatCount + len(sep)*(repeatCount-1))
+	for i := 0; i < repeatCount; i++ {
+		if i > 0 {
+			repeated.WriteString(sep)
+		}
+		repeated.WriteString(s)
+	}
+	return repeated.String()
+}
+
+func RepeatFunc(repeatCount int, f func(i int) string) string {
+	var repeated strings.Builder
 	for i := 0; i < repeatCount; i++ {
-		repeated += character
+		repeated.WriteString(f(i))
+	}
+	return repeated.String()
+}
+
+// CountRun reports how many back-to-back copies of sub s starts with, so
+// CountRun(Repeat(sub, n), sub) == n. An empty sub always counts as 0.
+func CountRun(s, sub string) int {
+	if sub == "" {
+		return 0
+	}
+	count := 0
+	for strings.HasPrefix(s, sub) {
+		s = s[len(sub):]
+		count++
+	}
+	return count
+}
+
+// RepeatToLength repeats s until the result is exactly maxLen runes long,
+// cutting the last copy short if needed.
+func RepeatToLength(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) == 0 || maxLen <= 0 {
+		return ""
+	}
+	filled := make([]rune, maxLen)
+	for i := range filled {
+		filled[i] = runes[i%len(runes)]
+	}
+	return string(filled)
+}
+
+// RepeatTo writes s to w repeatCount times and returns the number of bytes
+// written. It stops at the first write error.
+func RepeatTo(w io.Writer, s string, repeatCount int) (int, error) {
+	written := 0
+	for i := 0; i < repeatCount; i++ {
+		n, err := io.WriteString(w, s)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// IsRepetition reports whether s is a shorter string repeated two or more
+// times, returning the shortest such unit and how many times it repeats.
+func IsRepetition(s string) (unit string, times int, ok bool) {
+	for i := range s {
+		if i == 0 || len(s)%i != 0 {
+			continue
+		}
+		if Repeat(s[:i], len(s)/i) == s {
+			return s[:i], len(s) / i, true
+		}
+	}
+	return "", 0, false
+}
+
+// RepeatEqualFold reports whether s repeated repeatCount times equals other
+// under Unicode case-folding.
+func RepeatEqualFold(s string, repeatCount int, other string) bool {
+	return strings.EqualFold(Repeat(s, repeatCount), other)
+}
+
+// RepeatMap repeats s count times, passing each rune of the result through f
+// along with its rune position in the whole output. A nil f leaves the runes
+// unchanged.
+func RepeatMap(s string, count int, f func(r rune, i int) rune) string {
+	if f == nil {
+		return Repeat(s, count)
+	}
+	runes := []rune(Repeat(s, count))
+	for i, r := range runes {
+		runes[i] = f(r, i)
+	}
+	return string(runes)
+}
+
+// Alignment says where RepeatPad places the repeated text within its width.
+type Alignment int
+
+const (
+	Left Alignment = iota
+	Right
+	Center
+)
+
+// RepeatPad repeats s as many whole times as fit in total runes and pads the
+// rest with spaces according to align. Center puts any odd space on the
+// right. If s alone is longer than total it is cut to total runes.
+func RepeatPad(s string, total int, align Alignment) string {
+	runes := []rune(s)
+	if total <= 0 {
+		return ""
+	}
+	if len(runes) == 0 {
+		return Repeat(" ", total)
+	}
+	if len(runes) > total {
+		return string(runes[:total])
+	}
+
+	content := Repeat(s, total/len(runes))
+	leftover := total % len(runes)
+	switch align {
+	case Right:
+		return Repeat(" ", leftover) + content
+	case Center:
+		return Repeat(" ", leftover/2) + content + Repeat(" ", leftover-leftover/2)
+	default:
+		return content + Repeat(" ", leftover)
 	}
-	return repeated
 }
@@ -1,6 +1,17 @@
 package iteration
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
 
 func TestRepeat(t *testing.T) {
 	t.Run("Repeat the character 5 times", func(t *testing.T) {
@@ -14,6 +25,275 @@ func TestRepeat(t *testing.T) {
 		want := "bbb"
 		assertCorrectMessage(t, got, want)
 	})
+
+	t.Run("Repeat the character 0 times", func(t *testing.T) {
+		got := Repeat("a", 0)
+		want := ""
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat the character a negative number of times", func(t *testing.T) {
+		got := Repeat("a", -3)
+		want := ""
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat a multi-character string", func(t *testing.T) {
+		got := Repeat("ab", 3)
+		want := "ababab"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat a multibyte character", func(t *testing.T) {
+		got := Repeat("é", 2)
+		want := "éé"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat a CJK character", func(t *testing.T) {
+		got := Repeat("汉", 3)
+		want := "汉汉汉"
+		assertCorrectMessage(t, got, want)
+
+		if len(got) != 9 || utf8.RuneCountInString(got) != 3 {
+			t.Errorf("Expected 9 bytes and 3 runes but got %d bytes and %d runes", len(got), utf8.RuneCountInString(got))
+		}
+	})
+
+	t.Run("Repeat an emoji", func(t *testing.T) {
+		got := Repeat("👋🏽", 3)
+		want := "👋🏽👋🏽👋🏽"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat a combining character sequence", func(t *testing.T) {
+		got := Repeat("e\u0301", 2)
+		want := "e\u0301e\u0301"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat an empty string", func(t *testing.T) {
+		got := Repeat("", 5)
+		want := ""
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Repeat an empty string a huge number of times", func(t *testing.T) {
+		got := Repeat("", math.MaxInt)
+		want := ""
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Panics when the output length overflows", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "iteration: Repeat output length overflow" {
+				t.Errorf("Expected an overflow panic but got %v", r)
+			}
+		}()
+		Repeat("ab", math.MaxInt/2+1)
+	})
+}
+
+func TestRepeatWithSeparator(t *testing.T) {
+	t.Run("Separator between repetitions", func(t *testing.T) {
+		got := RepeatWithSeparator("x", 3, ", ")
+		want := "x, x, x"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("SQL parameter list", func(t *testing.T) {
+		got := RepeatWithSeparator("?", 4, ",")
+		want := "?,?,?,?"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("One repetition has no separator", func(t *testing.T) {
+		got := RepeatWithSeparator("x", 1, ", ")
+		want := "x"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Zero repetitions has no separator", func(t *testing.T) {
+		got := RepeatWithSeparator("x", 0, ", ")
+		want := ""
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Panics when the output length overflows", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "iteration: RepeatWithSeparator output length overflow" {
+				t.Errorf("Expected an overflow panic but got %v", r)
+			}
+		}()
+		RepeatWithSeparator("a", math.MaxInt/2+2, "b")
+	})
+}
+
+func TestRepeatFunc(t *testing.T) {
+	t.Run("Passes the index to each call", func(t *testing.T) {
+		got := RepeatFunc(3, strconv.Itoa)
+		want := "012"
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Generalises Repeat", func(t *testing.T) {
+		got := RepeatFunc(4, func(int) string { return "ab" })
+		want := Repeat("ab", 4)
+		assertCorrectMessage(t, got, want)
+	})
+
+	t.Run("Zero or negative count never calls f", func(t *testing.T) {
+		calls := 0
+		f := func(int) string {
+			calls++
+			return "x"
+		}
+
+		assertCorrectMessage(t, RepeatFunc(0, f), "")
+		assertCorrectMessage(t, RepeatFunc(-1, f), "")
+		if calls != 0 {
+			t.Errorf("Expected f not to be called but it was called %d times", calls)
+		}
+	})
+}
+
+func TestCountRun(t *testing.T) {
+	countRunTests := []struct {
+		name   string
+		s, sub string
+		want   int
+	}{
+		{"run at the start", "aaab", "a", 3},
+		{"no match", "baaa", "a", 0},
+		{"partial overlap", "aaa", "aa", 1},
+		{"multi-character run", "ababa", "ab", 2},
+		{"full string", "xyzxyz", "xyz", 2},
+		{"multibyte", "éééa", "é", 3},
+		{"empty sub", "aaa", "", 0},
+		{"empty s", "", "a", 0},
+	}
+
+	for _, tt := range countRunTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CountRun(tt.s, tt.sub)
+			if got != tt.want {
+				t.Errorf("Expected %d but got %d", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Inverse of Repeat", func(t *testing.T) {
+		if got := CountRun(Repeat("go", 7), "go"); got != 7 {
+			t.Errorf("Expected 7 but got %d", got)
+		}
+	})
+}
+
+func TestRepeatToLength(t *testing.T) {
+	repeatToLengthTests := []struct {
+		name   string
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"Truncates mid-repetition", "abc", 7, "abcabca"},
+		{"Exact fit", "abc", 6, "abcabc"},
+		{"Shorter than s", "abc", 2, "ab"},
+		{"Counts runes not bytes", "é汉", 3, "é汉é"},
+		{"Empty s", "", 5, ""},
+		{"Zero length", "abc", 0, ""},
+		{"Negative length", "abc", -1, ""},
+	}
+
+	for _, tt := range repeatToLengthTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RepeatToLength(tt.s, tt.maxLen)
+			assertCorrectMessage(t, got, tt.want)
+		})
+	}
+}
+
+func TestRepeatTo(t *testing.T) {
+	t.Run("Writes every repetition", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		n, err := RepeatTo(&buf, "ab", 3)
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+
+		assertCorrectMessage(t, buf.String(), "ababab")
+		if n != 6 {
+			t.Errorf("Expected 6 bytes written but got %d", n)
+		}
+	})
+
+	t.Run("Stops at the first write error", func(t *testing.T) {
+		w := &limitedWriter{limit: 5}
+
+		n, err := RepeatTo(w, "ab", 10)
+		if err != errLimitReached {
+			t.Errorf("got error %v want %v", err, errLimitReached)
+		}
+
+		assertCorrectMessage(t, w.buf.String(), "ababa")
+		if n != 5 {
+			t.Errorf("Expected 5 bytes written but got %d", n)
+		}
+	})
+
+	t.Run("Zero count writes nothing", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		n, err := RepeatTo(&buf, "ab", 0)
+		if err != nil || n != 0 || buf.Len() != 0 {
+			t.Errorf("Expected nothing written but got %d bytes, %q and error %v", n, buf.String(), err)
+		}
+	})
+}
+
+var errLimitReached = errors.New("write limit reached")
+
+// limitedWriter accepts up to limit bytes and then fails.
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	room := w.limit - w.buf.Len()
+	if len(p) <= room {
+		return w.buf.Write(p)
+	}
+	n, _ := w.buf.Write(p[:room])
+	return n, errLimitReached
+}
+
+func TestIsRepetition(t *testing.T) {
+	isRepetitionTests := []struct {
+		s     string
+		unit  string
+		times int
+		ok    bool
+	}{
+		{"abcabc", "abc", 2, true},
+		{"aaaa", "a", 4, true},
+		{"abababab", "ab", 4, true},
+		{"汉字汉字汉字", "汉字", 3, true},
+		{"abcd", "", 0, false},
+		{"abcab", "", 0, false},
+		{"a", "", 0, false},
+		{"", "", 0, false},
+	}
+
+	for _, tt := range isRepetitionTests {
+		t.Run(tt.s, func(t *testing.T) {
+			unit, times, ok := IsRepetition(tt.s)
+			if unit != tt.unit || times != tt.times || ok != tt.ok {
+				t.Errorf("Expected (%q, %d, %t) but got (%q, %d, %t)", tt.unit, tt.times, tt.ok, unit, times, ok)
+			}
+		})
+	}
 }
 
 func assertCorrectMessage(t testing.TB, got, want string) {
@@ -22,8 +302,221 @@ func assertCorrectMessage(t testing.TB, got, want string) {
 	}
 }
 
+func TestRepeatEqualFold(t *testing.T) {
+	equalFoldTests := []struct {
+		s     string
+		count int
+		other string
+		want  bool
+	}{
+		{"Ab", 2, "abAB", true},
+		{"ab", 2, "abab", true},
+		{"École", 2, "écoleÉCOLE", true},
+		{"Σσ", 1, "σΣ", true},
+		{"ab", 2, "aba", false},
+		{"ab", 3, "abab", false},
+		{"ab", 0, "", true},
+	}
+
+	for _, tt := range equalFoldTests {
+		t.Run(tt.other, func(t *testing.T) {
+			got := RepeatEqualFold(tt.s, tt.count, tt.other)
+			if got != tt.want {
+				t.Errorf("Expected %t for (%q, %d) but got %t", tt.want, tt.s, tt.count, got)
+			}
+		})
+	}
+}
+
+func TestRepeatMap(t *testing.T) {
+	alternateCase := func(r rune, i int) rune {
+		if i%2 == 0 {
+			return unicode.ToUpper(r)
+		}
+		return r
+	}
+
+	repeatMapTests := []struct {
+		name  string
+		s     string
+		count int
+		f     func(rune, int) rune
+		want  string
+	}{
+		{"every other rune upper-cased", "abc", 3, alternateCase, "AbCaBcAbC"},
+		{"positions count runes not bytes", "éa", 2, alternateCase, "ÉaÉa"},
+		{"nil func", "ab", 2, nil, "abab"},
+		{"zero count", "abc", 0, alternateCase, ""},
+		{"negative count", "abc", -1, alternateCase, ""},
+	}
+
+	for _, tt := range repeatMapTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RepeatMap(tt.s, tt.count, tt.f)
+			if got != tt.want {
+				t.Errorf("Expected %q but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepeatPad(t *testing.T) {
+	repeatPadTests := []struct {
+		name  string
+		s     string
+		total int
+		align Alignment
+		want  string
+	}{
+		{"left", "abc", 8, Left, "abcabc  "},
+		{"right", "abc", 8, Right, "  abcabc"},
+		{"center with even leftover", "abc", 8, Center, " abcabc "},
+		{"center with odd leftover", "abc", 7, Center, "abcabc "},
+		{"center with wider odd leftover", "abcd", 11, Center, " abcdabcd  "},
+		{"exact fit", "ab", 6, Center, "ababab"},
+		{"multibyte", "é", 3, Right, "ééé"},
+		{"truncates long content", "abcdef", 4, Right, "abcd"},
+		{"empty s is all padding", "", 3, Left, "   "},
+		{"zero total", "abc", 0, Left, ""},
+	}
+
+	for _, tt := range repeatPadTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RepeatPad(tt.s, tt.total, tt.align)
+			if got != tt.want {
+				t.Errorf("Expected %q but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func FuzzRepeat(f *testing.F) {
+	f.Add("a", uint8(5))
+	f.Add("", uint8(3))
+	f.Add("汉字", uint8(2))
+	f.Add("ab", uint8(0))
+
+	f.Fuzz(func(t *testing.T, s string, count uint8) {
+		// Invalid UTF-8 can join across copies into new runes, so rune
+		// counts wouldn't line up.
+		if !utf8.ValidString(s) {
+			t.Skip()
+		}
+		n := int(count % 16)
+		runes := []rune(s)
+		repeated := []rune(Repeat(s, n))
+
+		if len(repeated) != len(runes)*n {
+			t.Fatalf("Expected %d runes for %q x %d but got %d", len(runes)*n, s, n, len(repeated))
+		}
+		if n == 0 || len(runes) == 0 {
+			return
+		}
+		for i := 0; i < n; i++ {
+			chunk := string(repeated[i*len(runes) : (i+1)*len(runes)])
+			if chunk != s {
+				t.Fatalf("Expected chunk %d of %q x %d to be %q but got %q", i, s, n, s, chunk)
+			}
+		}
+	})
+}
+
 func BenchmarkRepeat(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Repeat("a", 5)
 	}
 }
+
+func BenchmarkRepeat_Small(b *testing.B)  { benchmarkRepeat(b, 5) }
+func BenchmarkRepeat_Medium(b *testing.B) { benchmarkRepeat(b, 1000) }
+func BenchmarkRepeat_Large(b *testing.B)  { benchmarkRepeat(b, 100000) }
+
+// benchmarkRepeat reports allocations so growth strategies can be compared;
+// Repeat should stay at one allocation per call whatever the count.
+func benchmarkRepeat(b *testing.B, repeatCount int) {
+	if got := Repeat("a", repeatCount); got != strings.Repeat("a", repeatCount) {
+		b.Fatalf("Repeat(\"a\", %d) gave %d bytes", repeatCount, len(got))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Repeat("a", repeatCount)
+	}
+}
+
+// repeatBuffers backs repeatPooled. strings.Builder can't be pooled usefully:
+// String shares its buffer and Reset throws it away, so bytes.Buffer is used
+// instead.
+var repeatBuffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// repeatPooled is the pooled-buffer alternative to Repeat that
+// BenchmarkRepeatPooled compares against. Converting the buffer to a string
+// still copies it, so it can't beat Repeat's single allocation.
+func repeatPooled(s string, repeatCount int) string {
+	if repeatCount <= 0 {
+		return ""
+	}
+	buf := repeatBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer repeatBuffers.Put(buf)
+
+	buf.Grow(len(s) * repeatCount)
+	for i := 0; i < repeatCount; i++ {
+		buf.WriteString(s)
+	}
+	return buf.String()
+}
+
+func TestRepeatPooled(t *testing.T) {
+	for _, repeatCount := range []int{-1, 0, 1, 5, 1000} {
+		if got, want := repeatPooled("汉a", repeatCount), Repeat("汉a", repeatCount); got != want {
+			t.Errorf("Expected %q for count %d but got %q", want, repeatCount, got)
+		}
+	}
+}
+
+func BenchmarkRepeatPooled(b *testing.B) {
+	for _, repeatCount := range []int{5, 1000, 100000} {
+		b.Run(fmt.Sprintf("builder/%d", repeatCount), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Repeat("a", repeatCount)
+			}
+		})
+		b.Run(fmt.Sprintf("pooled/%d", repeatCount), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				repeatPooled("a", repeatCount)
+			}
+		})
+	}
+}
+
+// repeatRunes is the rune-by-rune alternative to Repeat that
+// BenchmarkRepeatMultibyte compares against.
+func repeatRunes(s string, repeatCount int) string {
+	runes := []rune(s)
+	repeated := make([]rune, 0, len(runes)*repeatCount)
+	for i := 0; i < repeatCount; i++ {
+		repeated = append(repeated, runes...)
+	}
+	return string(repeated)
+}
+
+func BenchmarkRepeatMultibyte(b *testing.B) {
+	b.Run("strings", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Repeat("汉", 1000)
+		}
+	})
+
+	b.Run("runes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			repeatRunes("汉", 1000)
+		}
+	})
+}
@@ -1,9 +1,365 @@
 package main
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrLengthMismatch = errors.New("slices must have the same length")
+	ErrOverflow       = errors.New("sum overflows int")
+	ErrEmptySlice     = errors.New("slice is empty")
+	ErrZeroModulus    = errors.New("modulus must not be zero")
+	ErrOutOfRange     = errors.New("range out of bounds")
+)
+
+// Number is the set of integer and floating point types SumG accepts.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
 func Sum(numbers []int) int {
+	return SumG(numbers)
+}
+
+func SumG[T Number](numbers []T) T {
+	var sum T
+	for _, number := range numbers {
+		sum += number
+	}
+	return sum
+}
+
+// SumFloatKahan sums numbers using Kahan summation, carrying the low-order
+// bits lost by each addition into the next so that long slices accumulate
+// far less rounding error than SumG.
+func SumFloatKahan(numbers []float64) float64 {
+	sum, compensation := 0.0, 0.0
+	for _, number := range numbers {
+		y := number - compensation
+		t := sum + y
+		compensation = (t - sum) - y
+		sum = t
+	}
+	return sum
+}
+
+func SumAll(numbersToSum ...[]int) []int {
+	sums := make([]int, len(numbersToSum))
+	for i, numbers := range numbersToSum {
+		sums[i] = Sum(numbers)
+	}
+	return sums
+}
+
+func SumAllTails(numbersToSum ...[]int) []int {
+	sums := make([]int, len(numbersToSum))
+	for i, numbers := range numbersToSum {
+		if len(numbers) == 0 {
+			continue
+		}
+		sums[i] = Sum(numbers[1:])
+	}
+	return sums
+}
+
+// parallelThreshold is the slice length below which SumParallel doesn't
+// bother spawning goroutines.
+const parallelThreshold = 1024
+
+func SumParallel(numbers []int, workers int) int {
+	if workers <= 1 || len(numbers) < parallelThreshold {
+		return Sum(numbers)
+	}
+
+	chunkSize := (len(numbers) + workers - 1) / workers
+	partials := make(chan int)
+	chunks := 0
+	for start := 0; start < len(numbers); start += chunkSize {
+		end := start + chunkSize
+		if end > len(numbers) {
+			end = len(numbers)
+		}
+		chunks++
+		go func(chunk []int) {
+			partials <- Sum(chunk)
+		}(numbers[start:end])
+	}
+
+	sum := 0
+	for i := 0; i < chunks; i++ {
+		sum += <-partials
+	}
+	return sum
+}
+
+// SumChan adds up every value received from ch, blocking until ch is closed.
+func SumChan(ch <-chan int) int {
+	sum := 0
+	for number := range ch {
+		sum += number
+	}
+	return sum
+}
+
+func PrefixSums(numbers []int) []int {
+	sums := make([]int, len(numbers))
+	running := 0
+	for i, number := range numbers {
+		running += number
+		sums[i] = running
+	}
+	return sums
+}
+
+func SumProduct(a, b []int) (int, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%w: got %d and %d", ErrLengthMismatch, len(a), len(b))
+	}
+	sum := 0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum, nil
+}
+
+// OverflowPolicy decides what SumWithPolicy does when a sum overflows int.
+type OverflowPolicy int
+
+const (
+	// Wrap lets the sum wrap around, like Sum.
+	Wrap OverflowPolicy = iota
+	// Error reports ErrOverflow, like SumChecked.
+	Error
+	// Saturate clamps the running sum to math.MaxInt or math.MinInt.
+	Saturate
+)
+
+func SumWithPolicy(numbers []int, policy OverflowPolicy) (int, error) {
+	switch policy {
+	case Wrap:
+		return Sum(numbers), nil
+	case Error:
+		return SumChecked(numbers)
+	case Saturate:
+		return sumSaturating(numbers), nil
+	}
+	return 0, fmt.Errorf("unknown overflow policy %d", policy)
+}
+
+func sumSaturating(numbers []int) int {
+	sum := 0
+	for _, number := range numbers {
+		switch {
+		case number > 0 && sum > math.MaxInt-number:
+			sum = math.MaxInt
+		case number < 0 && sum < math.MinInt-number:
+			sum = math.MinInt
+		default:
+			sum += number
+		}
+	}
+	return sum
+}
+
+func SumChecked(numbers []int) (int, error) {
+	sum := 0
+	for _, number := range numbers {
+		next := sum + number
+		if (number > 0 && next < sum) || (number < 0 && next > sum) {
+			return 0, ErrOverflow
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+func Max(numbers []int) (int, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptySlice
+	}
+	max := numbers[0]
+	for _, number := range numbers[1:] {
+		if number > max {
+			max = number
+		}
+	}
+	return max, nil
+}
+
+// SumAndMax returns the sum and the largest of numbers in a single pass.
+func SumAndMax(numbers []int) (sum int, max int, err error) {
+	if len(numbers) == 0 {
+		return 0, 0, ErrEmptySlice
+	}
+	max = numbers[0]
+	for _, number := range numbers {
+		sum += number
+		if number > max {
+			max = number
+		}
+	}
+	return sum, max, nil
+}
+
+func Min(numbers []int) (int, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptySlice
+	}
+	min := numbers[0]
+	for _, number := range numbers[1:] {
+		if number < min {
+			min = number
+		}
+	}
+	return min, nil
+}
+
+// Average accumulates in float64 so neither integer division nor an
+// overflowing int sum can distort the result.
+func Average(numbers []int) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptySlice
+	}
+	sum := 0.0
+	for _, number := range numbers {
+		sum += float64(number)
+	}
+	return sum / float64(len(numbers)), nil
+}
+
+func SumCount(numbers []int) (sum, count int) {
+	for _, number := range numbers {
+		sum += number
+		count++
+	}
+	return sum, count
+}
+
+func SumStrings(values []string) (int, error) {
+	sum := 0
+	for i, value := range values {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("value %d (%q) is not an integer: %w", i, value, err)
+		}
+		sum += number
+	}
+	return sum, nil
+}
+
+func SumMatrix(grid [][]int) int {
+	sum := 0
+	for _, row := range grid {
+		sum += Sum(row)
+	}
+	return sum
+}
+
+// SumWhere sums the numbers for which pred returns true. A nil pred sums
+// every number, like Sum.
+func SumWhere(numbers []int, pred func(int) bool) int {
+	if pred == nil {
+		return Sum(numbers)
+	}
+	sum := 0
+	for _, number := range numbers {
+		if pred(number) {
+			sum += number
+		}
+	}
+	return sum
+}
+
+// SumAbs sums the absolute values of numbers. Rather than wrapping, it
+// saturates: math.MinInt counts as math.MaxInt, and a total that would pass
+// math.MaxInt is returned as math.MaxInt.
+func SumAbs(numbers []int) int {
 	sum := 0
 	for _, number := range numbers {
+		abs := number
+		switch {
+		case number == math.MinInt:
+			abs = math.MaxInt
+		case number < 0:
+			abs = -number
+		}
+		if abs > math.MaxInt-sum {
+			return math.MaxInt
+		}
+		sum += abs
+	}
+	return sum
+}
+
+// SumMod returns the Euclidean sum of numbers mod m, which is always in
+// [0, |m|). Each number is reduced before it is added, so the running total
+// never overflows however long numbers is.
+func SumMod(numbers []int, m int) (int, error) {
+	if m == 0 {
+		return 0, ErrZeroModulus
+	}
+	modulus := absUint(m)
+	var sum uint
+	for _, number := range numbers {
+		sum = (sum + euclidMod(number, modulus)) % modulus
+	}
+	return int(sum), nil
+}
+
+func euclidMod(n int, m uint) uint {
+	if n >= 0 {
+		return uint(n) % m
+	}
+	r := absUint(n) % m
+	if r == 0 {
+		return 0
+	}
+	return m - r
+}
+
+func absUint(n int) uint {
+	if n >= 0 {
+		return uint(n)
+	}
+	return uint(-(n + 1)) + 1
+}
+
+// SumRange sums numbers[start:end], reporting ErrOutOfRange instead of
+// panicking when the bounds don't fit numbers or end comes before start.
+func SumRange(numbers []int, start, end int) (int, error) {
+	if start < 0 || end > len(numbers) || start > end {
+		return 0, fmt.Errorf("%w: [%d, %d) of %d numbers", ErrOutOfRange, start, end, len(numbers))
+	}
+	return Sum(numbers[start:end]), nil
+}
+
+const progressSteps = 10
+
+// SumProgress sums numbers like Sum, calling onProgress about every tenth of
+// the way through and always once at the end with done == total. A nil
+// onProgress is never called.
+func SumProgress(numbers []int, onProgress func(done, total int)) int {
+	if onProgress == nil {
+		return Sum(numbers)
+	}
+	total := len(numbers)
+	step := total / progressSteps
+	if step < 1 {
+		step = 1
+	}
+
+	sum := 0
+	for i, number := range numbers {
 		sum += number
+		if done := i + 1; done%step == 0 && done != total {
+			onProgress(done, total)
+		}
 	}
+	onProgress(total, total)
 	return sum
 }
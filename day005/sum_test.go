@@ -1,6 +1,15 @@
 package main
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
 
 func TestSum(t *testing.T) {
 
@@ -15,3 +24,713 @@ func TestSum(t *testing.T) {
 		}
 	})
 }
+
+func TestSumG(t *testing.T) {
+
+	t.Run("float64 slice", func(t *testing.T) {
+		numbers := []float64{1.5, 2.25, 3}
+
+		got := SumG(numbers)
+		want := 6.75
+
+		if got != want {
+			t.Errorf("Expected %g given %v but got %g", want, numbers, got)
+		}
+	})
+
+	t.Run("int64 slice", func(t *testing.T) {
+		numbers := []int64{1 << 40, 1 << 40}
+
+		got := SumG(numbers)
+		want := int64(1 << 41)
+
+		if got != want {
+			t.Errorf("Expected %d given %v but got %d", want, numbers, got)
+		}
+	})
+
+	t.Run("empty slice returns the zero value", func(t *testing.T) {
+		got := SumG([]float32{})
+
+		if got != 0 {
+			t.Errorf("Expected 0 but got %g", got)
+		}
+	})
+}
+
+func TestSumFloatKahan(t *testing.T) {
+
+	t.Run("small values after a large one", func(t *testing.T) {
+		numbers := []float64{1e16}
+		for i := 0; i < 10000; i++ {
+			numbers = append(numbers, 1)
+		}
+		want := 1e16 + 10000
+
+		kahan := SumFloatKahan(numbers)
+		naive := SumG(numbers)
+
+		if math.Abs(kahan-want) >= math.Abs(naive-want) {
+			t.Errorf("Expected Kahan sum %f to be closer to %f than the naive sum %f", kahan, want, naive)
+		}
+		if kahan != want {
+			t.Errorf("Expected %f but got %f", want, kahan)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		if got := SumFloatKahan(nil); got != 0 {
+			t.Errorf("Expected 0 but got %f", got)
+		}
+	})
+}
+
+func TestSumAll(t *testing.T) {
+
+	t.Run("sums each slice", func(t *testing.T) {
+		got := SumAll([]int{1, 2}, []int{0, 9})
+		want := []int{3, 9}
+
+		assertSums(t, got, want)
+	})
+
+	t.Run("empty slices contribute 0", func(t *testing.T) {
+		got := SumAll([]int{}, []int{3, 4, 5}, nil)
+		want := []int{0, 12, 0}
+
+		assertSums(t, got, want)
+	})
+
+	t.Run("no slices", func(t *testing.T) {
+		got := SumAll()
+		want := []int{}
+
+		assertSums(t, got, want)
+	})
+}
+
+func TestSumAllTails(t *testing.T) {
+
+	t.Run("sums the tails of some slices", func(t *testing.T) {
+		got := SumAllTails([]int{1, 2, 3}, []int{0, 9})
+		want := []int{5, 9}
+
+		assertSums(t, got, want)
+	})
+
+	t.Run("safely sums empty slices", func(t *testing.T) {
+		got := SumAllTails([]int{}, nil, []int{3, 4, 5})
+		want := []int{0, 0, 9}
+
+		assertSums(t, got, want)
+	})
+
+	t.Run("single element slices have an empty tail", func(t *testing.T) {
+		got := SumAllTails([]int{7}, []int{-2})
+		want := []int{0, 0}
+
+		assertSums(t, got, want)
+	})
+}
+
+func TestSumParallel(t *testing.T) {
+
+	t.Run("matches Sum for large random slices", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, size := range []int{1024, 1025, 99999, 1_000_000} {
+			numbers := make([]int, size)
+			for i := range numbers {
+				numbers[i] = r.Intn(2000) - 1000
+			}
+
+			for _, workers := range []int{2, 3, 7, 8, 64} {
+				got := SumParallel(numbers, workers)
+				want := Sum(numbers)
+
+				if got != want {
+					t.Errorf("Expected %d for %d numbers and %d workers but got %d", want, size, workers, got)
+				}
+			}
+		}
+	})
+
+	t.Run("small slices and a single worker fall back to Sum", func(t *testing.T) {
+		numbers := []int{1, 2, 3}
+
+		for _, workers := range []int{-1, 0, 1, 4} {
+			got := SumParallel(numbers, workers)
+			if got != 6 {
+				t.Errorf("Expected 6 with %d workers but got %d", workers, got)
+			}
+		}
+	})
+}
+
+func TestSumChan(t *testing.T) {
+
+	t.Run("sums values sent from a goroutine", func(t *testing.T) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 1; i <= 100; i++ {
+				ch <- i
+			}
+		}()
+
+		got := SumChan(ch)
+		if got != 5050 {
+			t.Errorf("Expected %d but got %d", 5050, got)
+		}
+	})
+
+	t.Run("closed channel", func(t *testing.T) {
+		ch := make(chan int)
+		close(ch)
+
+		if got := SumChan(ch); got != 0 {
+			t.Errorf("Expected 0 but got %d", got)
+		}
+	})
+}
+
+func TestPrefixSums(t *testing.T) {
+
+	t.Run("cumulative sums", func(t *testing.T) {
+		numbers := []int{3, -1, 4, -1, -5}
+
+		got := PrefixSums(numbers)
+		want := []int{3, 2, 6, 5, 0}
+
+		assertSums(t, got, want)
+		if len(got) != len(numbers) {
+			t.Errorf("Expected %d sums but got %d", len(numbers), len(got))
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got := PrefixSums(nil)
+		want := []int{}
+
+		assertSums(t, got, want)
+	})
+}
+
+func TestSumProduct(t *testing.T) {
+
+	sumProductTests := []struct {
+		name string
+		a, b []int
+		want int
+	}{
+		{"positive numbers", []int{1, 2, 3}, []int{4, 5, 6}, 32},
+		{"with zeros", []int{0, 2, 0}, []int{7, 3, 9}, 6},
+		{"with negatives", []int{-1, 2}, []int{3, -4}, -11},
+		{"empty slices", nil, []int{}, 0},
+	}
+
+	for _, tt := range sumProductTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SumProduct(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d given %v and %v but got %d", tt.want, tt.a, tt.b, got)
+			}
+		})
+	}
+
+	t.Run("mismatched lengths", func(t *testing.T) {
+		_, err := SumProduct([]int{1, 2}, []int{1})
+		if !errors.Is(err, ErrLengthMismatch) {
+			t.Errorf("got error %v want %v", err, ErrLengthMismatch)
+		}
+	})
+}
+
+func TestSumChecked(t *testing.T) {
+
+	t.Run("sums without overflow", func(t *testing.T) {
+		numbers := []int{math.MaxInt - 2, 1, 1}
+
+		got, err := SumChecked(numbers)
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got != math.MaxInt {
+			t.Errorf("Expected %d given %v but got %d", math.MaxInt, numbers, got)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got, err := SumChecked(nil)
+		if err != nil || got != 0 {
+			t.Errorf("Expected 0, nil but got %d, %v", got, err)
+		}
+	})
+
+	t.Run("overflows just past MaxInt", func(t *testing.T) {
+		_, err := SumChecked([]int{math.MaxInt - 2, 1, 1, 1})
+		if err != ErrOverflow {
+			t.Errorf("got error %v want %v", err, ErrOverflow)
+		}
+	})
+
+	t.Run("overflows just past MinInt", func(t *testing.T) {
+		_, err := SumChecked([]int{math.MinInt + 1, -1, -1})
+		if err != ErrOverflow {
+			t.Errorf("got error %v want %v", err, ErrOverflow)
+		}
+	})
+}
+
+func TestSumWithPolicy(t *testing.T) {
+	overflowing := []int{math.MaxInt, 1, 1}
+	underflowing := []int{math.MinInt, -1}
+
+	t.Run("Wrap wraps around", func(t *testing.T) {
+		got, err := SumWithPolicy(overflowing, Wrap)
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if want := math.MinInt + 1; got != want {
+			t.Errorf("Expected %d given %v but got %d", want, overflowing, got)
+		}
+	})
+
+	t.Run("Error reports the overflow", func(t *testing.T) {
+		_, err := SumWithPolicy(overflowing, Error)
+		if err != ErrOverflow {
+			t.Errorf("got error %v want %v", err, ErrOverflow)
+		}
+	})
+
+	t.Run("Saturate clamps to MaxInt", func(t *testing.T) {
+		got, err := SumWithPolicy(overflowing, Saturate)
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got != math.MaxInt {
+			t.Errorf("Expected %d given %v but got %d", math.MaxInt, overflowing, got)
+		}
+	})
+
+	t.Run("Saturate clamps to MinInt", func(t *testing.T) {
+		got, _ := SumWithPolicy(underflowing, Saturate)
+		if got != math.MinInt {
+			t.Errorf("Expected %d given %v but got %d", math.MinInt, underflowing, got)
+		}
+	})
+
+	t.Run("Saturate keeps summing after clamping", func(t *testing.T) {
+		numbers := []int{math.MaxInt, 10, -5}
+		got, _ := SumWithPolicy(numbers, Saturate)
+		if want := math.MaxInt - 5; got != want {
+			t.Errorf("Expected %d given %v but got %d", want, numbers, got)
+		}
+	})
+
+	t.Run("every policy agrees without overflow", func(t *testing.T) {
+		numbers := []int{1, -2, 3}
+		for _, policy := range []OverflowPolicy{Wrap, Error, Saturate} {
+			got, err := SumWithPolicy(numbers, policy)
+			if err != nil || got != 2 {
+				t.Errorf("Expected 2, nil with policy %d but got %d, %v", policy, got, err)
+			}
+		}
+	})
+
+	t.Run("unknown policies are rejected", func(t *testing.T) {
+		if _, err := SumWithPolicy(nil, OverflowPolicy(42)); err == nil {
+			t.Error("expected an error for an unknown policy")
+		}
+	})
+}
+
+func TestMinMax(t *testing.T) {
+
+	minMaxTests := []struct {
+		name     string
+		numbers  []int
+		min, max int
+	}{
+		{"single element", []int{7}, 7, 7},
+		{"mixed", []int{3, -1, 4, 1, -5, 9}, -5, 9},
+		{"all negative", []int{-3, -8, -2}, -8, -2},
+	}
+
+	for _, tt := range minMaxTests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMin, err := Min(tt.numbers)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			gotMax, err := Max(tt.numbers)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+
+			if gotMin != tt.min || gotMax != tt.max {
+				t.Errorf("Expected min %d max %d given %v but got %d and %d", tt.min, tt.max, tt.numbers, gotMin, gotMax)
+			}
+		})
+	}
+
+	t.Run("empty slice", func(t *testing.T) {
+		if _, err := Min(nil); err != ErrEmptySlice {
+			t.Errorf("got error %v want %v", err, ErrEmptySlice)
+		}
+		if _, err := Max([]int{}); err != ErrEmptySlice {
+			t.Errorf("got error %v want %v", err, ErrEmptySlice)
+		}
+	})
+}
+
+func TestSumAndMax(t *testing.T) {
+
+	sumAndMaxTests := []struct {
+		name     string
+		numbers  []int
+		sum, max int
+	}{
+		{"several numbers", []int{3, -1, 4, 1, -5, 9}, 11, 9},
+		{"single element", []int{7}, 7, 7},
+		{"all negative", []int{-3, -8, -2}, -13, -2},
+	}
+
+	for _, tt := range sumAndMaxTests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSum, gotMax, err := SumAndMax(tt.numbers)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if gotSum != tt.sum || gotMax != tt.max {
+				t.Errorf("Expected sum %d max %d given %v but got %d and %d", tt.sum, tt.max, tt.numbers, gotSum, gotMax)
+			}
+		})
+	}
+
+	t.Run("empty slice", func(t *testing.T) {
+		_, _, err := SumAndMax([]int{})
+		if err != ErrEmptySlice {
+			t.Errorf("got error %v want %v", err, ErrEmptySlice)
+		}
+	})
+}
+
+func TestAverage(t *testing.T) {
+
+	averageTests := []struct {
+		name    string
+		numbers []int
+		want    float64
+	}{
+		{"single element", []int{7}, 7},
+		{"no integer truncation", []int{1, 2}, 1.5},
+		{"negatives", []int{-1, -2, -4}, -7.0 / 3},
+		{"large values", []int{math.MaxInt, math.MaxInt}, float64(math.MaxInt)},
+	}
+
+	for _, tt := range averageTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Average(tt.numbers)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %g given %v but got %g", tt.want, tt.numbers, got)
+			}
+		})
+	}
+
+	t.Run("empty slice", func(t *testing.T) {
+		if _, err := Average(nil); err != ErrEmptySlice {
+			t.Errorf("got error %v want %v", err, ErrEmptySlice)
+		}
+	})
+}
+
+func TestSumCount(t *testing.T) {
+
+	t.Run("sum and count", func(t *testing.T) {
+		numbers := []int{4, -1, 7}
+
+		sum, count := SumCount(numbers)
+
+		if sum != 10 || count != 3 {
+			t.Errorf("Expected 10, 3 given %v but got %d, %d", numbers, sum, count)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		sum, count := SumCount(nil)
+
+		if sum != 0 || count != 0 {
+			t.Errorf("Expected 0, 0 but got %d, %d", sum, count)
+		}
+	})
+}
+
+func TestSumStrings(t *testing.T) {
+
+	t.Run("valid list", func(t *testing.T) {
+		got, err := SumStrings([]string{"1", "2", "-3", "40"})
+		if err != nil {
+			t.Fatalf("did not expect an error but got one %v", err)
+		}
+		if got != 40 {
+			t.Errorf("Expected 40 but got %d", got)
+		}
+	})
+
+	t.Run("padded entries", func(t *testing.T) {
+		for _, value := range []string{" 2", "-3 "} {
+			_, err := SumStrings([]string{"1", value})
+			if !errors.Is(err, strconv.ErrSyntax) {
+				t.Errorf("got error %v given %q want it to wrap %v", err, value, strconv.ErrSyntax)
+			}
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		got, err := SumStrings(nil)
+		if err != nil || got != 0 {
+			t.Errorf("Expected 0, nil but got %d, %v", got, err)
+		}
+	})
+
+	t.Run("unparseable entry", func(t *testing.T) {
+		_, err := SumStrings([]string{"1", "two", "3"})
+		if err == nil {
+			t.Fatal("expected an error but didn't get one")
+		}
+		if !strings.Contains(err.Error(), `"two"`) {
+			t.Errorf("error %q should mention the offending value", err)
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("got error %v want it to wrap %v", err, strconv.ErrSyntax)
+		}
+	})
+}
+
+func TestSumMatrix(t *testing.T) {
+
+	sumMatrixTests := []struct {
+		name string
+		grid [][]int
+		want int
+	}{
+		{"square grid", [][]int{{1, 2}, {3, 4}}, 10},
+		{"ragged rows", [][]int{{1}, {2, 3, 4}, {5, 6}}, 21},
+		{"empty rows", [][]int{{}, {7}, nil}, 7},
+		{"empty grid", [][]int{}, 0},
+		{"nil grid", nil, 0},
+	}
+
+	for _, tt := range sumMatrixTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SumMatrix(tt.grid)
+			if got != tt.want {
+				t.Errorf("Expected %d given %v but got %d", tt.want, tt.grid, got)
+			}
+		})
+	}
+}
+
+func TestSumWhere(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+	greaterThan := func(limit int) func(int) bool {
+		return func(n int) bool { return n > limit }
+	}
+
+	sumWhereTests := []struct {
+		name    string
+		numbers []int
+		pred    func(int) bool
+		want    int
+	}{
+		{"even numbers", []int{1, 2, 3, 4, 5, 6}, isEven, 12},
+		{"negative even numbers", []int{-4, -3, 2}, isEven, -2},
+		{"greater than 3", []int{1, 5, 3, 10}, greaterThan(3), 15},
+		{"nothing matches", []int{1, 2, 3}, greaterThan(10), 0},
+		{"nil predicate sums everything", []int{1, 2, 3}, nil, 6},
+		{"empty slice", []int{}, isEven, 0},
+	}
+
+	for _, tt := range sumWhereTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SumWhere(tt.numbers, tt.pred)
+			if got != tt.want {
+				t.Errorf("Expected %d given %v but got %d", tt.want, tt.numbers, got)
+			}
+		})
+	}
+}
+
+func TestSumAbs(t *testing.T) {
+
+	sumAbsTests := []struct {
+		name    string
+		numbers []int
+		want    int
+	}{
+		{"positive numbers", []int{1, 2, 3}, 6},
+		{"negative numbers", []int{-1, 2, -3}, 6},
+		{"empty slice", []int{}, 0},
+		{"MinInt saturates", []int{math.MinInt}, math.MaxInt},
+		{"MinInt plus more stays saturated", []int{math.MinInt, 5}, math.MaxInt},
+		{"large total saturates", []int{math.MaxInt, -1}, math.MaxInt},
+		{"just below the limit", []int{math.MaxInt - 1, -1}, math.MaxInt},
+	}
+
+	for _, tt := range sumAbsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SumAbs(tt.numbers)
+			if got != tt.want {
+				t.Errorf("Expected %d given %v but got %d", tt.want, tt.numbers, got)
+			}
+		})
+	}
+}
+
+func TestSumMod(t *testing.T) {
+
+	sumModTests := []struct {
+		name    string
+		numbers []int
+		m       int
+		want    int
+	}{
+		{"small sum", []int{1, 2, 3}, 4, 2},
+		{"negative numbers", []int{-1, -2}, 5, 2},
+		{"mixed signs", []int{7, -10, 4}, 3, 1},
+		{"negative modulus", []int{-1}, -5, 4},
+		{"would overflow without reducing", []int{math.MaxInt, math.MaxInt, math.MaxInt}, 10, 1},
+		{"MinInt values", []int{math.MinInt, math.MinInt}, 7, 5},
+		{"empty slice", []int{}, 9, 0},
+	}
+
+	for _, tt := range sumModTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SumMod(tt.numbers, tt.m)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d given %v mod %d but got %d", tt.want, tt.numbers, tt.m, got)
+			}
+		})
+	}
+
+	t.Run("zero modulus", func(t *testing.T) {
+		_, err := SumMod([]int{1, 2}, 0)
+		if err != ErrZeroModulus {
+			t.Errorf("got error %v want %v", err, ErrZeroModulus)
+		}
+	})
+}
+
+func TestSumRange(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5}
+
+	sumRangeTests := []struct {
+		name       string
+		start, end int
+		want       int
+	}{
+		{"middle of the slice", 1, 4, 9},
+		{"full slice", 0, len(numbers), 15},
+		{"empty range", 2, 2, 0},
+		{"empty range at the end", 5, 5, 0},
+	}
+
+	for _, tt := range sumRangeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SumRange(numbers, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("did not expect an error but got one %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d given [%d, %d) of %v but got %d", tt.want, tt.start, tt.end, numbers, got)
+			}
+		})
+	}
+
+	for _, bounds := range [][2]int{{-1, 2}, {0, 6}, {4, 2}, {6, 6}} {
+		t.Run(fmt.Sprintf("rejects [%d, %d)", bounds[0], bounds[1]), func(t *testing.T) {
+			_, err := SumRange(numbers, bounds[0], bounds[1])
+			if !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("got error %v want %v", err, ErrOutOfRange)
+			}
+		})
+	}
+}
+
+func TestSumProgress(t *testing.T) {
+	type progress struct{ done, total int }
+	record := func(calls *[]progress) func(done, total int) {
+		return func(done, total int) {
+			*calls = append(*calls, progress{done, total})
+		}
+	}
+
+	t.Run("reports increasing progress and finishes at the total", func(t *testing.T) {
+		numbers := make([]int, 1005)
+		for i := range numbers {
+			numbers[i] = i
+		}
+
+		var calls []progress
+		got := SumProgress(numbers, record(&calls))
+
+		if want := Sum(numbers); got != want {
+			t.Errorf("Expected %d but got %d", want, got)
+		}
+		if len(calls) < progressSteps || len(calls) > progressSteps+1 {
+			t.Errorf("Expected about %d progress calls but got %d", progressSteps, len(calls))
+		}
+		for i, call := range calls {
+			if call.total != len(numbers) {
+				t.Errorf("Expected total %d but got %d", len(numbers), call.total)
+			}
+			if i > 0 && call.done <= calls[i-1].done {
+				t.Errorf("Expected progress to increase but got %v", calls)
+			}
+		}
+		if last := calls[len(calls)-1]; last.done != last.total {
+			t.Errorf("Expected the last call to be done but got %v", last)
+		}
+	})
+
+	t.Run("short slices report every element", func(t *testing.T) {
+		var calls []progress
+		SumProgress([]int{1, 2, 3}, record(&calls))
+
+		want := []progress{{1, 3}, {2, 3}, {3, 3}}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("Expected %v but got %v", want, calls)
+		}
+	})
+
+	t.Run("empty slice still finishes", func(t *testing.T) {
+		var calls []progress
+		got := SumProgress(nil, record(&calls))
+
+		if got != 0 || !reflect.DeepEqual(calls, []progress{{0, 0}}) {
+			t.Errorf("Expected 0 and one final call but got %d and %v", got, calls)
+		}
+	})
+
+	t.Run("nil callback", func(t *testing.T) {
+		if got := SumProgress([]int{1, 2, 3}, nil); got != 6 {
+			t.Errorf("Expected 6 but got %d", got)
+		}
+	})
+}
+
+func assertSums(t testing.TB, got, want []int) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
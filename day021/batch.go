@@ -0,0 +1,71 @@
+package blogrenderer
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// RenderAll renders each post to the writer that writerFor returns for it,
+// using up to GOMAXPROCS goroutines. Every writer is closed once its post is
+// rendered, even if rendering failed. After the first error no further posts
+// are started, but renders already in flight aren't cancelled: the error is
+// returned once they finish.
+func (r *PostRenderer) RenderAll(posts []Post, writerFor func(p Post) (io.WriteCloser, error)) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+
+	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for _, p := range posts {
+		workers <- struct{}{}
+		if failed() {
+			break
+		}
+		wg.Add(1)
+		go func(p Post) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			if err := r.renderTo(p, writerFor); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(p)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (r *PostRenderer) renderTo(p Post, writerFor func(p Post) (io.WriteCloser, error)) error {
+	w, err := writerFor(p)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.Title, err)
+	}
+	err = r.Render(w, p)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.Title, err)
+	}
+	return nil
+}
+
+func RenderAll(posts []Post, writerFor func(p Post) (io.WriteCloser, error)) error {
+	renderer, err := NewPostRenderer()
+	if err != nil {
+		return err
+	}
+	return renderer.RenderAll(posts, writerFor)
+}
@@ -0,0 +1,121 @@
+package blogrenderer_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	blogrenderer "day021"
+)
+
+func TestRenderAll(t *testing.T) {
+	var posts []blogrenderer.Post
+	for i := 1; i <= 20; i++ {
+		posts = append(posts, blogrenderer.Post{
+			Title: fmt.Sprintf("Post %d", i),
+			Body:  fmt.Sprintf("Body %d", i),
+		})
+	}
+
+	t.Run("it renders every post to its own writer", func(t *testing.T) {
+		factory := newFakeWriterFactory(nil)
+
+		if err := blogrenderer.RenderAll(posts, factory.writerFor); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(factory.writers) != len(posts) {
+			t.Fatalf("got %d writers want %d", len(factory.writers), len(posts))
+		}
+		for _, p := range posts {
+			w := factory.writers[p.Title]
+			if !strings.Contains(w.String(), "<h1>"+p.Title+"</h1>") {
+				t.Errorf("writer for %q got %q", p.Title, w.String())
+			}
+			if !w.closed {
+				t.Errorf("writer for %q was not closed", p.Title)
+			}
+		}
+	})
+
+	t.Run("it returns the error and still closes writers", func(t *testing.T) {
+		factory := newFakeWriterFactory(map[string]bool{"Post 3": true})
+
+		err := blogrenderer.RenderAll(posts, factory.writerFor)
+		if !errors.Is(err, errWriteFailed) {
+			t.Fatalf("got error %v want %v", err, errWriteFailed)
+		}
+		if !strings.Contains(err.Error(), "Post 3") {
+			t.Errorf("got error %q, want it to name the failing post", err)
+		}
+
+		for title, w := range factory.writers {
+			if !w.closed {
+				t.Errorf("writer for %q was not closed", title)
+			}
+		}
+	})
+
+	t.Run("it starts no posts after the first error", func(t *testing.T) {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+		factory := newFakeWriterFactory(map[string]bool{"Post 1": true})
+
+		err := blogrenderer.RenderAll(posts, factory.writerFor)
+		if !errors.Is(err, errWriteFailed) {
+			t.Fatalf("got error %v want %v", err, errWriteFailed)
+		}
+		if len(factory.writers) != 1 {
+			t.Errorf("got %d writers want only the failing post's", len(factory.writers))
+		}
+	})
+
+	t.Run("it returns writer factory errors", func(t *testing.T) {
+		factoryErr := errors.New("disk full")
+		err := blogrenderer.RenderAll(posts, func(blogrenderer.Post) (io.WriteCloser, error) {
+			return nil, factoryErr
+		})
+		if !errors.Is(err, factoryErr) {
+			t.Errorf("got error %v want %v", err, factoryErr)
+		}
+	})
+}
+
+type fakeWriter struct {
+	strings.Builder
+	fail   bool
+	closed bool
+}
+
+func (w *fakeWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		return 0, errWriteFailed
+	}
+	return w.Builder.Write(p)
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+type fakeWriterFactory struct {
+	mu      sync.Mutex
+	failing map[string]bool
+	writers map[string]*fakeWriter
+}
+
+func newFakeWriterFactory(failing map[string]bool) *fakeWriterFactory {
+	return &fakeWriterFactory{failing: failing, writers: map[string]*fakeWriter{}}
+}
+
+func (f *fakeWriterFactory) writerFor(p blogrenderer.Post) (io.WriteCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWriter{fail: f.failing[p.Title]}
+	f.writers[p.Title] = w
+	return w, nil
+}
@@ -0,0 +1,80 @@
+package blogrenderer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	htmlTagPattern       = regexp.MustCompile(`<[^>]*>`)
+	inlineCodePattern    = regexp.MustCompile("`([^`]+)`")
+	headingPrefixPattern = regexp.MustCompile(`(?m)^\s*#{1,6}\s+`)
+)
+
+// StripMarkdown removes Markdown syntax from body, keeping the text of
+// headings, emphasis and links. Fenced code blocks keep their contents
+// untouched while the fence lines themselves are dropped.
+func StripMarkdown(body string) string {
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(body, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if !inCode {
+			line = stripInline(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// stripInline swaps code spans for placeholders before removing links and
+// emphasis, so markers inside code are kept, as renderInline does for URLs.
+func stripInline(line string) string {
+	var spans []string
+	line = strings.ReplaceAll(line, "\x00", "")
+	line = inlineCodePattern.ReplaceAllStringFunc(line, func(m string) string {
+		spans = append(spans, inlineCodePattern.FindStringSubmatch(m)[1])
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+	line = headingPrefixPattern.ReplaceAllString(line, "")
+	line = linkPattern.ReplaceAllString(line, "$1")
+	line = boldPattern.ReplaceAllString(line, "$1")
+	line = italicPattern.ReplaceAllString(line, "$1")
+	return placeholderPattern.ReplaceAllStringFunc(line, func(m string) string {
+		i, _ := strconv.Atoi(strings.Trim(m, "\x00"))
+		return spans[i]
+	})
+}
+
+// plainText strips Markdown and HTML markup from body and collapses all
+// whitespace into single spaces.
+func plainText(body string) string {
+	text := htmlTagPattern.ReplaceAllString(StripMarkdown(body), "")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Excerpt returns the body as plain text, cut at the last word boundary
+// within maxRunes and followed by "…" when anything had to be cut.
+func Excerpt(p Post, maxRunes int) string {
+	text := []rune(plainText(p.Body))
+	if len(text) == 0 || len(text) <= maxRunes {
+		return string(text)
+	}
+	if maxRunes <= 0 {
+		return "…"
+	}
+
+	cut := maxRunes
+	for i := maxRunes; i > 0; i-- {
+		if text[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRight(string(text[:cut]), " .,;:!?") + "…"
+}
@@ -0,0 +1,70 @@
+package blogrenderer_test
+
+import (
+	"testing"
+
+	blogrenderer "day021"
+)
+
+func TestExcerpt(t *testing.T) {
+	excerptTests := []struct {
+		name     string
+		body     string
+		maxRunes int
+		want     string
+	}{
+		{"short body passes through", "A short post.", 50, "A short post."},
+		{"exactly the limit", "four", 4, "four"},
+		{"cuts at a word boundary", "The quick brown fox jumps", 12, "The quick…"},
+		{"cuts on the word end", "The quick brown fox", 9, "The quick…"},
+		{"drops trailing punctuation", "Hello, world and friends", 8, "Hello…"},
+		{"no word boundary", "Supercalifragilistic", 5, "Super…"},
+		{"markdown is stripped", "# Title\n\nSome **bold** and [a link](https://go.dev).", 100, "Title Some bold and a link."},
+		{"inline code and italics", "Call `Render` *now*, not_later", 100, "Call Render now, not_later"},
+		{"html is stripped", "<p>Hello <em>there</em></p>", 100, "Hello there"},
+		{"counts runes", "héllo wörld again", 11, "héllo wörld…"},
+		{"no limit cuts everything", "Some text", 0, "…"},
+		{"empty body with a negative limit", "", -1, ""},
+		{"markup-only body with no limit", "<br>", 0, ""},
+	}
+
+	for _, tt := range excerptTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blogrenderer.Excerpt(blogrenderer.Post{Body: tt.body}, tt.maxRunes)
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	stripTests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"plain text passes through", "Just words.", "Just words."},
+		{"headings", "# Title\n## Subtitle", "Title\nSubtitle"},
+		{"links keep their text", "Read [the docs](https://go.dev) today", "Read the docs today"},
+		{"bold and italic", "Some **bold** and *italic* text", "Some bold and italic text"},
+		{"inline code", "Call `Render` now", "Call Render now"},
+		{"inline code keeps emphasis markers", "Use `x*y*z` and *a*", "Use x*y*z and a"},
+		{"inline code keeps link syntax", "Write `[text](url)` for links", "Write [text](url) for links"},
+		{
+			"fenced code keeps its contents",
+			"Example:\n\n```go\nx := **y** * 2\n# not a heading\n```\n\nDone",
+			"Example:\n\nx := **y** * 2\n# not a heading\n\nDone",
+		},
+		{"tilde fences", "~~~\ncode\n~~~", "code"},
+	}
+
+	for _, tt := range stripTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blogrenderer.StripMarkdown(tt.body)
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
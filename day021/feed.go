@@ -0,0 +1,75 @@
+package blogrenderer
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+const (
+	feedTitle       = "Blog"
+	feedLink        = "/"
+	feedDescription = "Latest posts"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+}
+
+// rssDate formats t for RSS, leaving undated posts without a date.
+func rssDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+func RenderFeed(w io.Writer, posts []Post) error {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       feedTitle,
+			Link:        feedLink,
+			Description: feedDescription,
+		},
+	}
+	var newest time.Time
+	for _, p := range posts {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       p.Title,
+			Link:        "/post/" + Slug(p),
+			Description: p.Description,
+			Categories:  NormalizeTags(p.Tags),
+			PubDate:     rssDate(p.Date),
+		})
+		if p.Date.After(newest) {
+			newest = p.Date
+		}
+	}
+	feed.Channel.LastBuildDate = rssDate(newest)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(feed)
+}
@@ -0,0 +1,132 @@
+package blogrenderer_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	blogrenderer "day021"
+)
+
+type feed struct {
+	Version string `xml:"version,attr"`
+	Channel struct {
+		Title         string `xml:"title"`
+		LastBuildDate string `xml:"lastBuildDate"`
+		Items         []struct {
+			Title       string   `xml:"title"`
+			Link        string   `xml:"link"`
+			Description string   `xml:"description"`
+			Categories  []string `xml:"category"`
+			PubDate     string   `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+func TestRenderFeed(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "Hello World", Description: "The first post"},
+		{Title: "Fish & <Chips>", Description: `Say "hi" & bye`},
+	}
+
+	buf := bytes.Buffer{}
+	if err := blogrenderer.RenderFeed(&buf, posts); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("feed should start with the XML header, got %q", buf.String())
+	}
+
+	got := parseFeed(t, buf.Bytes())
+
+	if got.Version != "2.0" {
+		t.Errorf("got RSS version %q want %q", got.Version, "2.0")
+	}
+	if len(got.Channel.Items) != len(posts) {
+		t.Fatalf("got %d items want %d", len(got.Channel.Items), len(posts))
+	}
+	for i, item := range got.Channel.Items {
+		if item.Title != posts[i].Title || item.Description != posts[i].Description {
+			t.Errorf("item %d: got %q / %q want %q / %q", i, item.Title, item.Description, posts[i].Title, posts[i].Description)
+		}
+	}
+	if link := got.Channel.Items[0].Link; link != "/post/hello-world" {
+		t.Errorf("got link %q want %q", link, "/post/hello-world")
+	}
+}
+
+func TestRenderFeedCategories(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "Tagged", Tags: []string{"Go", "c++ & <html>", "go", " tdd "}},
+		{Title: "Untagged"},
+	}
+
+	buf := bytes.Buffer{}
+	if err := blogrenderer.RenderFeed(&buf, posts); err != nil {
+		t.Fatal(err)
+	}
+
+	got := parseFeed(t, buf.Bytes())
+
+	want := []string{"go", "c++ & <html>", "tdd"}
+	if !reflect.DeepEqual(got.Channel.Items[0].Categories, want) {
+		t.Errorf("got categories %q want %q", got.Channel.Items[0].Categories, want)
+	}
+	if cats := got.Channel.Items[1].Categories; len(cats) != 0 {
+		t.Errorf("got categories %q for an untagged post, want none", cats)
+	}
+	if strings.Contains(buf.String(), "<html>") {
+		t.Errorf("tags should be XML-escaped, got %s", buf.String())
+	}
+}
+
+func TestRenderFeedDates(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "Middle", Date: time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)},
+		{Title: "Undated"},
+		{Title: "Newest", Date: time.Date(2024, 3, 5, 18, 0, 0, 0, time.FixedZone("", 2*60*60))},
+	}
+
+	buf := bytes.Buffer{}
+	if err := blogrenderer.RenderFeed(&buf, posts); err != nil {
+		t.Fatal(err)
+	}
+
+	got := parseFeed(t, buf.Bytes())
+
+	wantPubDates := []string{"Thu, 01 Feb 2024 09:30:00 +0000", "", "Tue, 05 Mar 2024 18:00:00 +0200"}
+	for i, want := range wantPubDates {
+		if pubDate := got.Channel.Items[i].PubDate; pubDate != want {
+			t.Errorf("item %d: got pubDate %q want %q", i, pubDate, want)
+		}
+	}
+	if strings.Count(buf.String(), "<pubDate>") != 2 {
+		t.Errorf("undated posts should have no pubDate, got %s", buf.String())
+	}
+	if want := wantPubDates[2]; got.Channel.LastBuildDate != want {
+		t.Errorf("got lastBuildDate %q want %q", got.Channel.LastBuildDate, want)
+	}
+
+	t.Run("no lastBuildDate without dated posts", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		if err := blogrenderer.RenderFeed(&buf, posts[1:2]); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(buf.String(), "lastBuildDate") {
+			t.Errorf("got %s, want no lastBuildDate", buf.String())
+		}
+	})
+}
+
+func parseFeed(t testing.TB, data []byte) feed {
+	t.Helper()
+	var f feed
+	if err := xml.Unmarshal(data, &f); err != nil {
+		t.Fatalf("feed is not well-formed XML: %v", err)
+	}
+	return f
+}
@@ -0,0 +1,20 @@
+package blogrenderer
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func PostsToJSON(w io.Writer, posts []Post) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(posts)
+}
+
+func PostsFromJSON(r io.Reader) ([]Post, error) {
+	var posts []Post
+	if err := json.NewDecoder(r).Decode(&posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
@@ -0,0 +1,58 @@
+package blogrenderer_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	blogrenderer "day021"
+)
+
+func TestPostsJSON(t *testing.T) {
+	t.Run("posts survive a round trip", func(t *testing.T) {
+		posts := []blogrenderer.Post{
+			{
+				Title:       "hello world",
+				Description: "This is a description",
+				Body:        "This is a **post**",
+				Tags:        []string{"go", "tdd", "json"},
+				Date:        time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC),
+			},
+			{Title: "untagged", Draft: true},
+		}
+
+		buf := bytes.Buffer{}
+		if err := blogrenderer.PostsToJSON(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := blogrenderer.PostsFromJSON(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, posts) {
+			t.Errorf("got %+v want %+v", got, posts)
+		}
+	})
+
+	t.Run("fields use lower case names", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		if err := blogrenderer.PostsToJSON(&buf, []blogrenderer.Post{{Title: "hello"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(buf.String(), `"title": "hello"`) {
+			t.Errorf("got %s, want a lower case title field", buf.String())
+		}
+	})
+
+	t.Run("invalid JSON is an error", func(t *testing.T) {
+		_, err := blogrenderer.PostsFromJSON(strings.NewReader(`{"title": `))
+		if err == nil {
+			t.Error("expected an error but didn't get one")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package blogrenderer
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern   = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern = regexp.MustCompile(`\*(.+?)\*`)
+
+	placeholderPattern = regexp.MustCompile("\x00([0-9]+)\x00")
+)
+
+// renderMarkdown converts the small subset of Markdown we support - ATX
+// headings, paragraphs, **bold**, *italic* and [links](url) - into HTML. Any
+// raw HTML in the source is escaped rather than passed through.
+func renderMarkdown(src string) template.HTML {
+	var out strings.Builder
+	var paragraph []string
+	ids := headingIDs{}
+
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		out.WriteString("<p>" + renderInline(strings.Join(paragraph, "\n")) + "</p>")
+		paragraph = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			flush()
+			continue
+		}
+		if level, text, ok := parseHeading(trimmed); ok {
+			flush()
+			fmt.Fprintf(&out, `<h%d id="%s">%s</h%d>`, level, ids.next(text), renderInline(text), level)
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+	flush()
+
+	return template.HTML(out.String())
+}
+
+// headingIDs hands out slug ids for headings, suffixing repeats with -1, -2
+// and so on so that every id in a document is unique.
+type headingIDs map[string]bool
+
+func (ids headingIDs) next(text string) string {
+	base := slugify(text)
+	if base == "" {
+		base = "section"
+	}
+	id := base
+	for n := 1; ids[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	ids[id] = true
+	return id
+}
+
+func parseHeading(line string) (level int, text string, ok bool) {
+	level = len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(rest), true
+}
+
+// renderInline swaps links for placeholders before applying emphasis, so
+// emphasis markers inside a URL are left alone.
+func renderInline(text string) string {
+	var links []string
+	escaped := html.EscapeString(strings.ReplaceAll(text, "\x00", ""))
+	escaped = linkPattern.ReplaceAllStringFunc(escaped, func(m string) string {
+		parts := linkPattern.FindStringSubmatch(m)
+		link := renderEmphasis(parts[1])
+		if isSafeURL(html.UnescapeString(parts[2])) {
+			link = `<a href="` + parts[2] + `">` + link + `</a>`
+		}
+		links = append(links, link)
+		return fmt.Sprintf("\x00%d\x00", len(links)-1)
+	})
+	escaped = renderEmphasis(escaped)
+	return placeholderPattern.ReplaceAllStringFunc(escaped, func(m string) string {
+		i, _ := strconv.Atoi(strings.Trim(m, "\x00"))
+		return links[i]
+	})
+}
+
+func renderEmphasis(text string) string {
+	text = boldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	return italicPattern.ReplaceAllString(text, "<em>$1</em>")
+}
+
+func isSafeURL(url string) bool {
+	scheme, _, found := strings.Cut(url, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return true
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
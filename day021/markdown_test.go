@@ -0,0 +1,39 @@
+package blogrenderer
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	markdownTests := []struct {
+		name string
+		src  string
+		want template.HTML
+	}{
+		{"plain paragraph", "This is a post", "<p>This is a post</p>"},
+		{"paragraph breaks", "one\ntwo\n\nthree", "<p>one\ntwo</p><p>three</p>"},
+		{"headings", "# Title\n## Sub heading", `<h1 id="title">Title</h1><h2 id="sub-heading">Sub heading</h2>`},
+		{"heading ids are slugified", "## Getting Started!", `<h2 id="getting-started">Getting Started!</h2>`},
+		{"suffixes skip ids already taken", "## Setup 1\n## Setup\n## Setup", `<h2 id="setup-1">Setup 1</h2><h2 id="setup">Setup</h2><h2 id="setup-2">Setup</h2>`},
+		{"duplicate headings get unique ids", "## Setup\n## Setup\n### Setup", `<h2 id="setup">Setup</h2><h2 id="setup-1">Setup</h2><h3 id="setup-2">Setup</h3>`},
+		{"not a heading without a space", "#hashtag", "<p>#hashtag</p>"},
+		{"bold and italic", "**bold** and *italic*", "<p><strong>bold</strong> and <em>italic</em></p>"},
+		{"links", "see [Go](https://go.dev)", `<p>see <a href="https://go.dev">Go</a></p>`},
+		{"relative links", "[about](/about)", `<p><a href="/about">about</a></p>`},
+		{"emphasis markers in urls are left alone", "[docs](https://e.com/a_*b*_c)", `<p><a href="https://e.com/a_*b*_c">docs</a></p>`},
+		{"bold around a link with markers in its url", "**[l](https://e.com/**x**)**", `<p><strong><a href="https://e.com/**x**">l</a></strong></p>`},
+		{"emphasis inside link text", "[*Go* docs](https://go.dev)", `<p><a href="https://go.dev"><em>Go</em> docs</a></p>`},
+		{"unsafe links keep only their text", "[click](javascript:evil)", "<p>click</p>"},
+		{"raw html is escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+	}
+
+	for _, tt := range markdownTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderMarkdown(tt.src)
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
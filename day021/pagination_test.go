@@ -0,0 +1,76 @@
+package blogrenderer_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	blogrenderer "day021"
+)
+
+func TestRenderIndexPage(t *testing.T) {
+	var posts []blogrenderer.Post
+	for i, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+		posts = append(posts, blogrenderer.Post{
+			Title: title,
+			Date:  time.Date(2024, time.January, 5-i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+
+	pageTests := []struct {
+		name string
+		page int
+		want string
+	}{
+		{
+			"first page",
+			1,
+			`<ol><li><a href="/post/one">One</a></li><li><a href="/post/two">Two</a></li></ol><nav><a href="/page/2">Next</a></nav>`,
+		},
+		{
+			"middle page",
+			2,
+			`<ol><li><a href="/post/three">Three</a></li><li><a href="/post/four">Four</a></li></ol><nav><a href="/page/1">Previous</a><a href="/page/3">Next</a></nav>`,
+		},
+		{
+			"last page",
+			3,
+			`<ol><li><a href="/post/five">Five</a></li></ol><nav><a href="/page/2">Previous</a></nav>`,
+		},
+		{"past the last page", 4, `<ol></ol>`},
+		{"before the first page", 0, `<ol></ol>`},
+	}
+
+	for _, tt := range pageTests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			if err := blogrenderer.RenderIndexPage(&buf, posts, tt.page, 2); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("a single page has no navigation", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		if err := blogrenderer.RenderIndexPage(&buf, posts[:1], 1, 10); err != nil {
+			t.Fatal(err)
+		}
+
+		want := `<ol><li><a href="/post/one">One</a></li></ol>`
+		if got := buf.String(); got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("posts per page must be positive", func(t *testing.T) {
+		err := blogrenderer.RenderIndexPage(&bytes.Buffer{}, posts, 1, 0)
+		if !errors.Is(err, blogrenderer.ErrInvalidPerPage) {
+			t.Errorf("got error %v want %v", err, blogrenderer.ErrInvalidPerPage)
+		}
+	})
+}
@@ -0,0 +1,393 @@
+package blogrenderer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	titleKey       = "Title"
+	descriptionKey = "Description"
+	tagsKey        = "Tags"
+	dateKey        = "Date"
+	updatedKey     = "Updated"
+	draftKey       = "Draft"
+	bodySeparator  = "---"
+)
+
+var (
+	ErrMalformedPost = errors.New("malformed post")
+	ErrInvalidPost   = errors.New("invalid post")
+)
+
+// Validate reports every required field that p is missing.
+func (p Post) Validate() error {
+	var missing []string
+	if strings.TrimSpace(p.Title) == "" {
+		missing = append(missing, "title")
+	}
+	if strings.TrimSpace(p.Body) == "" {
+		missing = append(missing, "body")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrInvalidPost, strings.Join(missing, " and "))
+	}
+	return nil
+}
+
+func PostsFromFS(fileSystem fs.FS) ([]Post, error) {
+	posts, _, err := PostsFromFSVerbose(fileSystem)
+	return posts, err
+}
+
+// PostsFromFSVerbose reads posts like PostsFromFS and also returns warnings
+// about metadata that was accepted but looks like a mistake, such as a tag
+// listed twice. Each warning is prefixed with the file it came from.
+func PostsFromFSVerbose(fileSystem fs.FS) ([]Post, []string, error) {
+	dir, err := fs.ReadDir(fileSystem, ".")
+	if err != nil {
+		return nil, nil, err
+	}
+	var posts []Post
+	var warnings []string
+	for _, f := range dir {
+		if f.IsDir() || path.Ext(f.Name()) != ".md" {
+			continue
+		}
+		post, postWarnings, err := getPost(fileSystem, f.Name())
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		for _, w := range postWarnings {
+			warnings = append(warnings, f.Name()+": "+w)
+		}
+		posts = append(posts, post)
+	}
+	return posts, warnings, nil
+}
+
+func getPost(fileSystem fs.FS, fileName string) (Post, []string, error) {
+	postFile, err := fileSystem.Open(fileName)
+	if err != nil {
+		return Post{}, nil, err
+	}
+	defer postFile.Close()
+	return newPost(postFile)
+}
+
+// newPost parses a post made of a metadata block of "Key: value" lines,
+// terminated by a "---" line, followed by the body. Blank lines around the
+// metadata are ignored and Title and Description are required. Problems that
+// don't stop the post from loading are returned as warnings.
+func newPost(postBody io.Reader) (Post, []string, error) {
+	scanner := bufio.NewScanner(postBody)
+
+	var post Post
+	var seen, warnings []string
+	for {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return Post{}, nil, err
+			}
+			return Post{}, nil, fmt.Errorf("%w: missing %q before the body", ErrMalformedPost, bodySeparator)
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == bodySeparator {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return Post{}, nil, fmt.Errorf("%w: metadata line %q is not of the form \"Key: value\"", ErrMalformedPost, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case titleKey:
+			post.Title = value
+		case descriptionKey:
+			post.Description = value
+		case tagsKey:
+			tags := strings.Split(value, ",")
+			for _, tag := range duplicateTags(tags) {
+				warnings = append(warnings, fmt.Sprintf("duplicate tag %q", tag))
+			}
+			post.Tags = NormalizeTags(tags)
+		case dateKey:
+			date, err := parseDate(value)
+			if err != nil {
+				return Post{}, nil, fmt.Errorf("%w: %v", ErrMalformedPost, err)
+			}
+			post.Date = date
+		case updatedKey:
+			updated, err := parseDate(value)
+			if err != nil {
+				return Post{}, nil, fmt.Errorf("%w: %v", ErrMalformedPost, err)
+			}
+			post.Updated = updated
+		case draftKey:
+			draft, err := strconv.ParseBool(value)
+			if err != nil {
+				return Post{}, nil, fmt.Errorf("%w: invalid draft flag %q", ErrMalformedPost, value)
+			}
+			post.Draft = draft
+		default:
+			continue
+		}
+		seen = append(seen, key)
+	}
+
+	var missing []string
+	for _, key := range []string{titleKey, descriptionKey} {
+		if !slices.Contains(seen, key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return Post{}, nil, fmt.Errorf("%w: missing required field(s) %s", ErrMalformedPost, strings.Join(missing, ", "))
+	}
+
+	post.Body = readBody(scanner)
+	if err := scanner.Err(); err != nil {
+		return Post{}, nil, err
+	}
+	return post, warnings, nil
+}
+
+// WritePost writes p in the format newPost reads: the metadata lines, the
+// "---" separator and then the body, followed by a final newline. Empty tags, dates and draft flags are
+// left out. A post that wouldn't read back exactly as it was written is
+// reported as ErrMalformedPost; see roundTripProblem.
+func WritePost(w io.Writer, p Post) error {
+	if problem := roundTripProblem(p); problem != "" {
+		return fmt.Errorf("%w: %s", ErrMalformedPost, problem)
+	}
+
+	fields := [][2]string{{titleKey, p.Title}, {descriptionKey, p.Description}}
+	if len(p.Tags) > 0 {
+		fields = append(fields, [2]string{tagsKey, strings.Join(p.Tags, ", ")})
+	}
+	if !p.Date.IsZero() {
+		fields = append(fields, [2]string{dateKey, formatDate(p.Date)})
+	}
+	if !p.Updated.IsZero() {
+		fields = append(fields, [2]string{updatedKey, formatDate(p.Updated)})
+	}
+	if p.Draft {
+		fields = append(fields, [2]string{draftKey, "true"})
+	}
+
+	var out strings.Builder
+	for _, field := range fields {
+		fmt.Fprintf(&out, "%s: %s\n", field[0], field[1])
+	}
+	out.WriteString(bodySeparator + "\n" + p.Body + "\n")
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// roundTripProblem describes the first part of p that newPost would change
+// when reading it back, or returns "" if there is none. newPost trims
+// metadata, normalizes tags, reads dates back in UTC, and drops leading
+// blank lines and carriage returns from the body.
+func roundTripProblem(p Post) string {
+	for _, field := range [][2]string{{titleKey, p.Title}, {descriptionKey, p.Description}} {
+		switch {
+		case strings.ContainsAny(field[1], "\r\n"):
+			return fmt.Sprintf("%s %q spans several lines", field[0], field[1])
+		case strings.TrimSpace(field[1]) != field[1]:
+			return fmt.Sprintf("%s %q has surrounding whitespace", field[0], field[1])
+		}
+	}
+	for _, tag := range p.Tags {
+		if strings.Contains(tag, ",") {
+			return fmt.Sprintf("tag %q contains a comma", tag)
+		}
+	}
+	if normalized := NormalizeTags(p.Tags); !slices.Equal(normalized, p.Tags) {
+		return fmt.Sprintf("tags %q are not normalized, want %q", p.Tags, normalized)
+	}
+	dates := []struct {
+		key  string
+		date time.Time
+	}{{dateKey, p.Date}, {updatedKey, p.Updated}}
+	for _, d := range dates {
+		if !d.date.IsZero() && d.date.Location() != time.UTC {
+			return fmt.Sprintf("%s %v is not in UTC", d.key, d.date)
+		}
+	}
+	firstLine, _, _ := strings.Cut(p.Body, "\n")
+	switch {
+	case strings.Contains(p.Body, "\r"):
+		return "body contains a carriage return"
+	case p.Body != "" && strings.TrimSpace(firstLine) == "":
+		return "body starts with a blank line"
+	}
+	return ""
+}
+
+// formatDate is the inverse of parseDate, using the short form whenever it
+// loses nothing.
+func formatDate(date time.Time) string {
+	if date.Equal(date.Truncate(24*time.Hour)) && date.Location() == time.UTC {
+		return date.Format(time.DateOnly)
+	}
+	return date.Format(time.RFC3339Nano)
+}
+
+// duplicateTags returns each normalized tag that appears more than once in
+// tags, in the order the repeats are found.
+func duplicateTags(tags []string) []string {
+	var duplicates []string
+	count := map[string]int{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if count[tag]++; count[tag] == 2 {
+			duplicates = append(duplicates, tag)
+		}
+	}
+	return duplicates
+}
+
+// parseDate accepts either a plain "2006-01-02" date or a full RFC 3339
+// timestamp.
+func parseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+func readBody(scanner *bufio.Scanner) string {
+	var lines []string
+	for scanner.Scan() {
+		if len(lines) == 0 && strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		lines = append(lines, scanner.Text())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SortPostsByDate sorts posts newest first, in place. Undated posts go last
+// and posts with equal dates keep their relative order.
+func SortPostsByDate(posts []Post) {
+	slices.SortStableFunc(posts, func(a, b Post) int {
+		switch {
+		case a.Date.IsZero() && b.Date.IsZero():
+			return 0
+		case a.Date.IsZero():
+			return 1
+		case b.Date.IsZero():
+			return -1
+		}
+		return b.Date.Compare(a.Date)
+	})
+}
+
+// PrevNext finds current in posts, which must be sorted newest first as by
+// SortPostsByDate, and returns the post published before it and the one
+// published after it. Posts are matched by slug, or by title when the slug is
+// empty, and either result is nil at the ends of posts or when current isn't
+// found.
+func PrevNext(posts []Post, current Post) (prev, next *Post) {
+	key := postKey(current)
+	for i := range posts {
+		if postKey(posts[i]) != key {
+			continue
+		}
+		if i+1 < len(posts) {
+			prev = &posts[i+1]
+		}
+		if i > 0 {
+			next = &posts[i-1]
+		}
+		return prev, next
+	}
+	return nil, nil
+}
+
+const undatedArchiveKey = "undated"
+
+// ArchiveByMonth groups posts by the "2006-01" month they were published in,
+// newest first within each month. Undated posts are grouped under "undated".
+func ArchiveByMonth(posts []Post) map[string][]Post {
+	archive := map[string][]Post{}
+	for _, p := range posts {
+		key := undatedArchiveKey
+		if !p.Date.IsZero() {
+			key = p.Date.Format("2006-01")
+		}
+		archive[key] = append(archive[key], p)
+	}
+	for _, month := range archive {
+		SortPostsByDate(month)
+	}
+	return archive
+}
+
+// PostsInRange returns the posts dated between from and to inclusive, in
+// their original order. A zero from or to leaves that end of the range open,
+// and undated posts are only included when both are zero.
+func PostsInRange(posts []Post, from, to time.Time) []Post {
+	inRange := []Post{}
+	bounded := !from.IsZero() || !to.IsZero()
+	for _, p := range posts {
+		if bounded && p.Date.IsZero() ||
+			!from.IsZero() && p.Date.Before(from) ||
+			!to.IsZero() && p.Date.After(to) {
+			continue
+		}
+		inRange = append(inRange, p)
+	}
+	return inRange
+}
+
+func PublishedPosts(posts []Post) []Post {
+	published := []Post{}
+	for _, p := range posts {
+		if !p.Draft {
+			published = append(published, p)
+		}
+	}
+	return published
+}
+
+// DedupePosts drops posts whose slug has already been seen, keeping whichever
+// copy has the latest Date, or the first one when the dates are equal. Each
+// surviving post stays where its slug first appeared. Posts with an empty
+// slug are compared by title instead.
+func DedupePosts(posts []Post) []Post {
+	deduped := []Post{}
+	index := map[string]int{}
+	for _, p := range posts {
+		key := postKey(p)
+		i, seen := index[key]
+		switch {
+		case !seen:
+			index[key] = len(deduped)
+			deduped = append(deduped, p)
+		case p.Date.After(deduped[i].Date):
+			deduped[i] = p
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,569 @@
+package blogrenderer_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	blogrenderer "day021"
+)
+
+func TestPostsFromFS(t *testing.T) {
+	const (
+		firstBody = `Title: Post 1
+Description: Description 1
+Tags: tdd, go
+---
+Hello
+World`
+		secondBody = `Title: Post 2
+Description: Description 2
+Tags: rust, borrow-checker
+---
+B
+L
+M`
+	)
+
+	t.Run("it reads every markdown file", func(t *testing.T) {
+		fs := fstest.MapFS{
+			"hello world.md":  {Data: []byte(firstBody)},
+			"hello-world2.md": {Data: []byte(secondBody)},
+			"notes.txt":       {Data: []byte("not a post")},
+		}
+
+		posts, err := blogrenderer.PostsFromFS(fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(posts) != 2 {
+			t.Fatalf("got %d posts, want 2", len(posts))
+		}
+		assertPost(t, posts[0], blogrenderer.Post{
+			Title:       "Post 1",
+			Description: "Description 1",
+			Tags:        []string{"tdd", "go"},
+			Body: `Hello
+World`,
+		})
+	})
+
+	t.Run("it reports malformed files", func(t *testing.T) {
+		fs := fstest.MapFS{
+			"broken.md": {Data: []byte("Description: no title\n---\nbody")},
+		}
+
+		_, err := blogrenderer.PostsFromFS(fs)
+		if !errors.Is(err, blogrenderer.ErrMalformedPost) {
+			t.Errorf("got error %v want %v", err, blogrenderer.ErrMalformedPost)
+		}
+	})
+}
+
+func TestPostsFromFSVerbose(t *testing.T) {
+	t.Run("it warns about duplicate tags but still loads the post", func(t *testing.T) {
+		fs := fstest.MapFS{
+			"dupes.md": {Data: []byte("Title: Dupes\nDescription: d\nTags: go, tdd, Go, go\n---\nbody")},
+		}
+
+		posts, warnings, err := blogrenderer.PostsFromFSVerbose(fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(posts) != 1 {
+			t.Fatalf("got %d posts, want 1", len(posts))
+		}
+		if wantTags := []string{"go", "tdd"}; !slices.Equal(posts[0].Tags, wantTags) {
+			t.Errorf("got tags %q want %q", posts[0].Tags, wantTags)
+		}
+
+		want := []string{`dupes.md: duplicate tag "go"`}
+		if !slices.Equal(warnings, want) {
+			t.Errorf("got warnings %q want %q", warnings, want)
+		}
+	})
+
+	t.Run("it has no warnings for clean metadata", func(t *testing.T) {
+		fs := fstest.MapFS{
+			"clean.md": {Data: []byte("Title: Clean\nDescription: d\nTags: go, tdd\n---\nbody")},
+		}
+
+		_, warnings, err := blogrenderer.PostsFromFSVerbose(fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("got warnings %q want none", warnings)
+		}
+	})
+}
+
+func TestPostFrontmatter(t *testing.T) {
+	parse := func(t *testing.T, src string) (blogrenderer.Post, error) {
+		t.Helper()
+		posts, err := blogrenderer.PostsFromFS(fstest.MapFS{"post.md": {Data: []byte(src)}})
+		if err != nil {
+			return blogrenderer.Post{}, err
+		}
+		return posts[0], nil
+	}
+
+	t.Run("well-formed input", func(t *testing.T) {
+		post, err := parse(t, "Title:   Hello  \nDescription: A post\nTags: go,  tdd ,, \n---\nBody")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertPost(t, post, blogrenderer.Post{
+			Title:       "Hello",
+			Description: "A post",
+			Tags:        []string{"go", "tdd"},
+			Body:        "Body",
+		})
+	})
+
+	t.Run("tags are normalized", func(t *testing.T) {
+		post, err := parse(t, "Title: Hello\nDescription: A post\nTags: Go, TDD, go\n---\nBody")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"go", "tdd"}
+		if !reflect.DeepEqual(post.Tags, want) {
+			t.Errorf("got tags %q want %q", post.Tags, want)
+		}
+	})
+
+	t.Run("tags are optional", func(t *testing.T) {
+		post, err := parse(t, "Title: Hello\nDescription: A post\n---\nBody")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if post.Tags != nil {
+			t.Errorf("got tags %v, want none", post.Tags)
+		}
+	})
+
+	t.Run("extra blank lines before the body", func(t *testing.T) {
+		post, err := parse(t, "\nTitle: Hello\n\nDescription: A post\n\n---\n\n\nFirst line\n\nSecond line")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "First line\n\nSecond line"
+		if post.Body != want {
+			t.Errorf("got body %q want %q", post.Body, want)
+		}
+	})
+
+	missingTests := []struct {
+		name    string
+		src     string
+		missing string
+	}{
+		{"missing title", "Description: A post\n---\nBody", "Title"},
+		{"missing description", "Title: Hello\n---\nBody", "Description"},
+		{"missing both", "Tags: go\n---\nBody", "Title, Description"},
+	}
+
+	for _, tt := range missingTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(t, tt.src)
+			if !errors.Is(err, blogrenderer.ErrMalformedPost) {
+				t.Fatalf("got error %v want %v", err, blogrenderer.ErrMalformedPost)
+			}
+			if !strings.Contains(err.Error(), tt.missing) {
+				t.Errorf("got error %q, want it to mention %q", err, tt.missing)
+			}
+		})
+	}
+
+	t.Run("dates", func(t *testing.T) {
+		post, err := parse(t, "Title: Hello\nDescription: A post\nDate: 2024-01-02\n---\nBody")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+		if !post.Date.Equal(want) {
+			t.Errorf("got date %v want %v", post.Date, want)
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		_, err := parse(t, "Title: Hello\nDescription: A post\nDate: yesterday\n---\nBody")
+		if !errors.Is(err, blogrenderer.ErrMalformedPost) {
+			t.Errorf("got error %v want %v", err, blogrenderer.ErrMalformedPost)
+		}
+	})
+
+	t.Run("drafts", func(t *testing.T) {
+		post, err := parse(t, "Title: Hello\nDescription: A post\nDraft: true\n---\nBody")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !post.Draft {
+			t.Error("expected the post to be a draft")
+		}
+	})
+
+	t.Run("invalid draft flag", func(t *testing.T) {
+		_, err := parse(t, "Title: Hello\nDescription: A post\nDraft: maybe\n---\nBody")
+		if !errors.Is(err, blogrenderer.ErrMalformedPost) {
+			t.Errorf("got error %v want %v", err, blogrenderer.ErrMalformedPost)
+		}
+	})
+
+	t.Run("missing body separator", func(t *testing.T) {
+		_, err := parse(t, "Title: Hello\nDescription: A post\nBody")
+		if !errors.Is(err, blogrenderer.ErrMalformedPost) {
+			t.Errorf("got error %v want %v", err, blogrenderer.ErrMalformedPost)
+		}
+	})
+}
+
+func TestWritePost(t *testing.T) {
+	roundTripTests := []struct {
+		name string
+		post blogrenderer.Post
+	}{
+		{"minimal post", blogrenderer.Post{Title: "Hello", Description: "A post", Body: "Hi"}},
+		{"with several tags", blogrenderer.Post{
+			Title:       "Tagged",
+			Description: "Has tags",
+			Tags:        []string{"go", "tdd", "borrow-checker"},
+			Body:        "# Heading\n\nSome **bold** text\n---\nmore",
+		}},
+		{"with a date and draft flag", blogrenderer.Post{
+			Title:       "Dated",
+			Description: "Has a date",
+			Date:        time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC),
+			Draft:       true,
+			Body:        "Body",
+		}},
+		{"with inner blank lines in the body", blogrenderer.Post{
+			Title:       "Spaced",
+			Description: "Blank lines",
+			Body:        "first\n\n\nlast  ",
+		}},
+		{"with an empty body", blogrenderer.Post{Title: "Empty", Description: "No body"}},
+		{"with a trailing newline in the body", blogrenderer.Post{Title: "Trailing", Description: "Ends with a newline", Body: "hello\n"}},
+		{"with a timestamp", blogrenderer.Post{
+			Title:       "Timed",
+			Description: "Has a time",
+			Date:        time.Date(2024, 3, 5, 14, 30, 15, 500, time.UTC),
+			Body:        "Body",
+		}},
+	}
+
+	for _, tt := range roundTripTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := blogrenderer.WritePost(&buf, tt.post); err != nil {
+				t.Fatal(err)
+			}
+
+			posts, err := blogrenderer.PostsFromFS(fstest.MapFS{"post.md": {Data: []byte(buf.String())}})
+			if err != nil {
+				t.Fatalf("could not read back %q: %v", buf.String(), err)
+			}
+			assertPost(t, posts[0], tt.post)
+		})
+	}
+
+	t.Run("it writes the frontmatter format", func(t *testing.T) {
+		var buf strings.Builder
+		post := blogrenderer.Post{Title: "Hello", Description: "A post", Tags: []string{"go", "tdd"}, Body: "Hi"}
+		if err := blogrenderer.WritePost(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "Title: Hello\nDescription: A post\nTags: go, tdd\n---\nHi\n"
+		if buf.String() != want {
+			t.Errorf("got %q want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("it writes back a loaded file that ends with a blank line", func(t *testing.T) {
+		src := "Title: x\nDescription: y\n---\nhello\n\n"
+		posts, err := blogrenderer.PostsFromFS(fstest.MapFS{"post.md": {Data: []byte(src)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf strings.Builder
+		if err := blogrenderer.WritePost(&buf, posts[0]); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != src {
+			t.Errorf("got %q want %q", buf.String(), src)
+		}
+	})
+
+	t.Run("it rejects posts it can't read back unchanged", func(t *testing.T) {
+		lossyTests := []struct {
+			name string
+			post blogrenderer.Post
+		}{
+			{"multi-line title", blogrenderer.Post{Title: "two\nlines", Description: "d"}},
+			{"padded description", blogrenderer.Post{Title: "t", Description: " d "}},
+			{"tag with a comma", blogrenderer.Post{Title: "t", Description: "d", Tags: []string{"a,b"}}},
+			{"upper case tag", blogrenderer.Post{Title: "t", Description: "d", Tags: []string{"Go"}}},
+			{"duplicate tags", blogrenderer.Post{Title: "t", Description: "d", Tags: []string{"go", "go"}}},
+			{"padded tag", blogrenderer.Post{Title: "t", Description: "d", Tags: []string{" go"}}},
+			{"body with a leading blank line", blogrenderer.Post{Title: "t", Description: "d", Body: "\nb"}},
+			{"body with windows line endings", blogrenderer.Post{Title: "t", Description: "d", Body: "a\r\nb"}},
+			{"date outside UTC", blogrenderer.Post{Title: "t", Description: "d", Date: time.Date(2024, 3, 5, 10, 0, 0, 0, time.FixedZone("X", 3600))}},
+			{"updated outside UTC", blogrenderer.Post{Title: "t", Description: "d", Updated: time.Date(2024, 3, 5, 10, 0, 0, 0, time.FixedZone("X", 3600))}},
+		}
+
+		for _, tt := range lossyTests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := blogrenderer.WritePost(io.Discard, tt.post)
+				if !errors.Is(err, blogrenderer.ErrMalformedPost) {
+					t.Errorf("got error %v want %v", err, blogrenderer.ErrMalformedPost)
+				}
+			})
+		}
+	})
+
+	t.Run("it returns write errors", func(t *testing.T) {
+		err := blogrenderer.WritePost(failingWriter{}, blogrenderer.Post{Title: "t", Description: "d"})
+		if !errors.Is(err, errWriteFailed) {
+			t.Errorf("got error %v want %v", err, errWriteFailed)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid post", func(t *testing.T) {
+		post := blogrenderer.Post{Title: "Hello", Body: "World"}
+
+		if err := post.Validate(); err != nil {
+			t.Errorf("did not expect an error but got one %v", err)
+		}
+	})
+
+	invalidTests := []struct {
+		name string
+		post blogrenderer.Post
+		want string
+	}{
+		{"missing title", blogrenderer.Post{Body: "World"}, "invalid post: missing title"},
+		{"missing body", blogrenderer.Post{Title: "Hello", Body: "  "}, "invalid post: missing body"},
+		{"missing both", blogrenderer.Post{Description: "only a description"}, "invalid post: missing title and body"},
+	}
+
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.post.Validate()
+			if !errors.Is(err, blogrenderer.ErrInvalidPost) {
+				t.Fatalf("got error %v want %v", err, blogrenderer.ErrInvalidPost)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got error %q want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortPostsByDate(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	posts := []blogrenderer.Post{
+		{Title: "undated 1"},
+		{Title: "oldest", Date: day(1)},
+		{Title: "newest", Date: day(20)},
+		{Title: "undated 2"},
+		{Title: "middle a", Date: day(10)},
+		{Title: "middle b", Date: day(10)},
+	}
+
+	blogrenderer.SortPostsByDate(posts)
+
+	assertTitles(t, posts, "newest", "middle a", "middle b", "oldest", "undated 1", "undated 2")
+}
+
+func TestPrevNext(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "Newest", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Title: "Middle", Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Title: "Oldest", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	title := func(p *blogrenderer.Post) string {
+		if p == nil {
+			return "<nil>"
+		}
+		return p.Title
+	}
+
+	prevNextTests := []struct {
+		name     string
+		current  blogrenderer.Post
+		wantPrev string
+		wantNext string
+	}{
+		{"the newest post has no next", posts[0], "Middle", "<nil>"},
+		{"a middle post has both", posts[1], "Oldest", "Newest"},
+		{"the oldest post has no prev", posts[2], "<nil>", "Middle"},
+		{"it matches by slug", blogrenderer.Post{Title: "middle"}, "Oldest", "Newest"},
+		{"an unknown post has neither", blogrenderer.Post{Title: "Missing"}, "<nil>", "<nil>"},
+	}
+
+	for _, tt := range prevNextTests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next := blogrenderer.PrevNext(posts, tt.current)
+			if title(prev) != tt.wantPrev || title(next) != tt.wantNext {
+				t.Errorf("got prev %q next %q want prev %q next %q", title(prev), title(next), tt.wantPrev, tt.wantNext)
+			}
+		})
+	}
+
+	t.Run("posts without a slug are matched by title", func(t *testing.T) {
+		posts := []blogrenderer.Post{{Title: "你好"}, {Title: "世界"}}
+
+		prev, next := blogrenderer.PrevNext(posts, posts[1])
+		if title(prev) != "<nil>" || title(next) != "你好" {
+			t.Errorf("got prev %q next %q want prev %q next %q", title(prev), title(next), "<nil>", "你好")
+		}
+	})
+}
+
+func TestArchiveByMonth(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	posts := []blogrenderer.Post{
+		{Title: "early jan", Date: date(time.January, 3)},
+		{Title: "feb", Date: date(time.February, 14)},
+		{Title: "no date"},
+		{Title: "late jan", Date: date(time.January, 28)},
+	}
+
+	archive := blogrenderer.ArchiveByMonth(posts)
+
+	if len(archive) != 3 {
+		t.Errorf("got %d months want 3: %v", len(archive), archive)
+	}
+	assertTitles(t, archive["2024-01"], "late jan", "early jan")
+	assertTitles(t, archive["2024-02"], "feb")
+	assertTitles(t, archive["undated"], "no date")
+}
+
+func TestPostsInRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	posts := []blogrenderer.Post{
+		{Title: "5th", Date: day(5)},
+		{Title: "undated"},
+		{Title: "1st", Date: day(1)},
+		{Title: "10th", Date: day(10)},
+		{Title: "20th", Date: day(20)},
+	}
+
+	rangeTests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{"both bounds", day(2), day(15), []string{"5th", "10th"}},
+		{"boundaries are inclusive", day(5), day(10), []string{"5th", "10th"}},
+		{"no lower bound", time.Time{}, day(5), []string{"5th", "1st"}},
+		{"no upper bound", day(10), time.Time{}, []string{"10th", "20th"}},
+		{"no bounds", time.Time{}, time.Time{}, []string{"5th", "undated", "1st", "10th", "20th"}},
+		{"inverted bounds", day(15), day(2), nil},
+	}
+
+	for _, tt := range rangeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTitles(t, blogrenderer.PostsInRange(posts, tt.from, tt.to), tt.want...)
+		})
+	}
+}
+
+func TestPublishedPosts(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "published 1"},
+		{Title: "draft 1", Draft: true},
+		{Title: "published 2"},
+		{Title: "draft 2", Draft: true},
+	}
+
+	assertTitles(t, blogrenderer.PublishedPosts(posts), "published 1", "published 2")
+	assertTitles(t, blogrenderer.PublishedPosts(posts[1:2]))
+}
+
+func TestDedupePosts(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("the newer of two same-slug posts survives", func(t *testing.T) {
+		posts := []blogrenderer.Post{
+			{Title: "Hello World", Description: "old", Date: older},
+			{Title: "Other"},
+			{Title: "hello, world!", Description: "new", Date: newer},
+		}
+
+		got := blogrenderer.DedupePosts(posts)
+
+		assertTitles(t, got, "hello, world!", "Other")
+		if got[0].Description != "new" {
+			t.Errorf("got %+v, want the newer post", got[0])
+		}
+	})
+
+	t.Run("the first post wins on equal dates", func(t *testing.T) {
+		posts := []blogrenderer.Post{
+			{Title: "Post", Description: "first", Date: older},
+			{Title: "Post", Description: "second", Date: older},
+		}
+
+		got := blogrenderer.DedupePosts(posts)
+
+		if len(got) != 1 || got[0].Description != "first" {
+			t.Errorf("got %+v, want only the first post", got)
+		}
+	})
+
+	t.Run("posts without a slug are told apart by title", func(t *testing.T) {
+		posts := []blogrenderer.Post{{Title: "你好"}, {Title: "世界"}, {Title: "!!!"}, {Title: "你好"}}
+
+		got := blogrenderer.DedupePosts(posts)
+
+		assertTitles(t, got, "你好", "世界", "!!!")
+	})
+
+	t.Run("no posts", func(t *testing.T) {
+		assertTitles(t, blogrenderer.DedupePosts(nil))
+	})
+}
+
+func assertTitles(t testing.TB, posts []blogrenderer.Post, want ...string) {
+	t.Helper()
+	got := make([]string, len(posts))
+	for i, p := range posts {
+		got[i] = p.Title
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got titles %q want %q", got, want)
+	}
+}
+
+func assertPost(t *testing.T, got blogrenderer.Post, want blogrenderer.Post) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
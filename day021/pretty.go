@@ -0,0 +1,139 @@
+package blogrenderer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTokenPattern = regexp.MustCompile(`<[^>]+>|[^<]+`)
+	tagNamePattern   = regexp.MustCompile(`^</?([a-zA-Z0-9]+)`)
+	whitespaceRun    = regexp.MustCompile(`\s+`)
+)
+
+// blockElements are the elements indentHTML puts on lines of their own.
+var blockElements = map[string]bool{
+	"html": true, "head": true, "body": true, "nav": true, "div": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"p": true, "ul": true, "ol": true, "li": true,
+	"title": true, "meta": true,
+}
+
+var voidElements = map[string]bool{
+	"meta": true, "link": true, "br": true, "hr": true, "img": true, "input": true,
+}
+
+// htmlLayout is the whitespace formatHTML puts around block elements.
+type htmlLayout struct {
+	indent  string
+	newline string
+}
+
+var (
+	indentedLayout = htmlLayout{indent: "  ", newline: "\n"}
+	minifiedLayout = htmlLayout{}
+)
+
+type htmlNode struct {
+	name     string // empty for text and other leaf tokens
+	open     string
+	close    string
+	text     string
+	children []*htmlNode
+}
+
+func (n *htmlNode) isBlock() bool {
+	return blockElements[n.name] || strings.HasPrefix(n.text, "<!")
+}
+
+func (n *htmlNode) hasBlockChildren() bool {
+	for _, c := range n.children {
+		if c.isBlock() || c.hasBlockChildren() {
+			return true
+		}
+	}
+	return false
+}
+
+// indentHTML re-flows the markup produced by our templates so that every
+// block element starts on its own line, indented by nesting depth. Only
+// whitespace between and around elements changes.
+func indentHTML(src string) string {
+	return formatHTML(src, indentedLayout)
+}
+
+// minifyHTML drops the whitespace between and around block elements and
+// collapses what is left inside text to single spaces.
+func minifyHTML(src string) string {
+	return formatHTML(src, minifiedLayout)
+}
+
+func formatHTML(src string, layout htmlLayout) string {
+	root := &htmlNode{}
+	stack := []*htmlNode{root}
+	for _, token := range htmlTokenPattern.FindAllString(src, -1) {
+		parent := stack[len(stack)-1]
+		match := tagNamePattern.FindStringSubmatch(token)
+		switch {
+		case match == nil:
+			parent.children = append(parent.children, &htmlNode{text: token})
+		case strings.HasPrefix(token, "</"):
+			if len(stack) > 1 {
+				parent.close = token
+				stack = stack[:len(stack)-1]
+			}
+		default:
+			node := &htmlNode{name: strings.ToLower(match[1]), open: token}
+			parent.children = append(parent.children, node)
+			if !voidElements[node.name] && !strings.HasSuffix(token, "/>") {
+				stack = append(stack, node)
+			}
+		}
+	}
+
+	var out strings.Builder
+	writeChildren(&out, root.children, layout, 0)
+	return strings.TrimSuffix(out.String(), layout.newline)
+}
+
+func writeChildren(out *strings.Builder, children []*htmlNode, layout htmlLayout, depth int) {
+	var line strings.Builder
+	writeLine := func(s string) {
+		out.WriteString(strings.Repeat(layout.indent, depth) + s + layout.newline)
+	}
+	flush := func() {
+		if text := strings.TrimSpace(line.String()); text != "" {
+			writeLine(text)
+		}
+		line.Reset()
+	}
+
+	for _, c := range children {
+		if !c.isBlock() {
+			line.WriteString(inlineHTML(c))
+			continue
+		}
+		flush()
+		if !c.hasBlockChildren() {
+			writeLine(strings.TrimSpace(inlineHTML(c)))
+			continue
+		}
+		writeLine(c.open)
+		writeChildren(out, c.children, layout, depth+1)
+		writeLine(c.close)
+	}
+	flush()
+}
+
+func inlineHTML(n *htmlNode) string {
+	if n.name == "" {
+		return whitespaceRun.ReplaceAllString(n.text, " ")
+	}
+	var b strings.Builder
+	b.WriteString(n.open)
+	for _, c := range n.children {
+		b.WriteString(inlineHTML(c))
+	}
+	b.WriteString(n.close)
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package blogrenderer
+
+import "testing"
+
+func TestIndentHTML(t *testing.T) {
+	indentTests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			"block elements get their own lines",
+			"<h1>Title</h1>\n\n<p>Some <strong>bold</strong> and <em>italic</em> text</p>",
+			"<h1>Title</h1>\n<p>Some <strong>bold</strong> and <em>italic</em> text</p>",
+		},
+		{
+			"nested blocks are indented",
+			"Tags: <ul><li>go</li><li>tdd</li></ul>",
+			"Tags:\n<ul>\n  <li>go</li>\n  <li>tdd</li>\n</ul>",
+		},
+		{
+			"documents",
+			"<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>T</title></head><body><h1>T</h1></body></html>",
+			"<!DOCTYPE html>\n<html>\n  <head>\n    <meta charset=\"utf-8\">\n    <title>T</title>\n  </head>\n  <body>\n    <h1>T</h1>\n  </body>\n</html>",
+		},
+		{
+			"anchors stay inline",
+			`<ol><li><a href="/post/a">A</a></li></ol>`,
+			"<ol>\n  <li><a href=\"/post/a\">A</a></li>\n</ol>",
+		},
+	}
+
+	for _, tt := range indentTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := indentHTML(tt.src)
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinifyHTML(t *testing.T) {
+	minifyTests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			"whitespace between blocks is dropped",
+			"<h1>Title</h1>\n\n<p>Some  <strong>bold</strong>\n text</p>\n\n",
+			"<h1>Title</h1><p>Some <strong>bold</strong> text</p>",
+		},
+		{
+			"nested blocks",
+			"Tags: <ul>\n  <li>go</li>\n  <li>tdd</li>\n</ul>",
+			"Tags:<ul><li>go</li><li>tdd</li></ul>",
+		},
+		{
+			"documents",
+			"<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n</head>\n<body>\n<h1>T</h1>\n</body>\n</html>",
+			"<!DOCTYPE html><html><head><meta charset=\"utf-8\"></head><body><h1>T</h1></body></html>",
+		},
+	}
+
+	for _, tt := range minifyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := minifyHTML(tt.src)
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
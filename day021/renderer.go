@@ -1,28 +1,349 @@
 package blogrenderer
 
 import (
+	"bytes"
+	"context"
 	"embed"
+	"errors"
+	"fmt"
 	"html/template"
 	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
 //go:embed "templates/*"
 var postTemplates embed.FS
 
+var (
+	ErrInvalidPerPage  = errors.New("posts per page must be positive")
+	ErrMissingTemplate = errors.New("missing required template")
+)
+
+// postTemplateName is the block that renders a single post. Custom template
+// files must define it.
+const postTemplateName = "post"
+
 type Post struct {
-	Title, Description, Body string
-	Tags                     []string
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Body        string    `json:"body"`
+	Tags        []string  `json:"tags"`
+	Date        time.Time `json:"date"`
+	Updated     time.Time `json:"updated"`
+	Draft       bool      `json:"draft,omitempty"`
+}
+
+var templateFuncs = template.FuncMap{
+	"slug":      Slug,
+	"pluralize": pluralize,
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+// TagStyle chooses how a post's tags are rendered.
+type TagStyle int
+
+const (
+	// TagList renders tags as a labelled <ul> list.
+	TagList TagStyle = iota
+	// TagHashtags renders tags as a line of hashtags, e.g. "#go #tdd".
+	TagHashtags
+)
+
+// RenderOptions customises the markup of a rendered post. Empty class names
+// leave the class attribute out.
+type RenderOptions struct {
+	TitleClass       string
+	DescriptionClass string
+	TagsClass        string
+	TagStyle         TagStyle
+}
+
+type postViewModel struct {
+	Post
+	HTMLBody       template.HTML
+	ReadingMinutes int
+	Options        RenderOptions
+	Hashtags       string
+	LastUpdated    string
+}
+
+func newPostViewModel(p Post, opts RenderOptions) postViewModel {
+	vm := postViewModel{
+		Post:           p,
+		HTMLBody:       renderMarkdown(p.Body),
+		ReadingMinutes: int(ReadingTime(p).Minutes()),
+		Options:        opts,
+	}
+	if opts.TagStyle == TagHashtags {
+		vm.Hashtags = hashtags(p.Tags)
+	}
+	if p.Updated.After(p.Date) {
+		vm.LastUpdated = p.Updated.Format(time.DateOnly)
+	}
+	return vm
+}
+
+func hashtags(tags []string) string {
+	var out []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, "#"+tag)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+type PostRenderer struct {
+	templ *template.Template
+
+	// ExcludeDrafts leaves draft posts out of the index.
+	ExcludeDrafts bool
+
+	// Pretty indents the rendered HTML so each block element sits on its own
+	// line. Pretty output is buffered rather than streamed, and
+	// RenderIndexContext ignores it.
+	Pretty bool
+
+	// Minify strips the whitespace between and around block elements. Like
+	// Pretty it buffers the output, and Pretty wins if both are set.
+	Minify bool
+
+	Options RenderOptions
 }
 
+// RendererOption configures a PostRenderer as it is created.
+type RendererOption func(*rendererConfig)
+
+type rendererConfig struct {
+	funcs template.FuncMap
+}
+
+// WithFuncs makes funcs available to the templates, replacing any built-in
+// function with the same name.
+func WithFuncs(funcs template.FuncMap) RendererOption {
+	return func(c *rendererConfig) {
+		maps.Copy(c.funcs, funcs)
+	}
+}
+
+func newRendererConfig(opts []RendererOption) rendererConfig {
+	config := rendererConfig{funcs: maps.Clone(templateFuncs)}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+func NewPostRenderer(opts ...RendererOption) (*PostRenderer, error) {
+	config := newRendererConfig(opts)
+	templ, err := template.New("").Funcs(config.funcs).ParseFS(postTemplates, "templates/*.gohtml")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostRenderer{templ: templ}, nil
+}
+
+// NewPostRendererFromFile is like NewPostRenderer but reads the post template
+// from templatePath, which must define a "post" block. The embedded templates
+// are still used for everything the file doesn't define.
+func NewPostRendererFromFile(templatePath string, opts ...RendererOption) (*PostRenderer, error) {
+	src, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(templatePath)
+
+	custom, err := template.New(name).Funcs(newRendererConfig(opts).funcs).Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	if custom.Lookup(postTemplateName) == nil {
+		return nil, fmt.Errorf("%w: %s does not define %q", ErrMissingTemplate, templatePath, postTemplateName)
+	}
+
+	renderer, err := NewPostRenderer(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := renderer.templ.New(name).Parse(string(src)); err != nil {
+		return nil, err
+	}
+	return renderer, nil
+}
+
+// Render executes the post template straight into w, so output is written
+// piece by piece rather than buffered, and any error from w is returned.
+func (r *PostRenderer) Render(w io.Writer, p Post) error {
+	return r.execute(w, postTemplateName, newPostViewModel(p, r.Options))
+}
+
+// RenderTee renders p once, writing the same output to every writer. It
+// stops at the first writer that fails and returns its error.
+func (r *PostRenderer) RenderTee(p Post, writers ...io.Writer) error {
+	return r.Render(io.MultiWriter(writers...), p)
+}
+
+// RenderPage renders p as a complete, standalone HTML document.
+func (r *PostRenderer) RenderPage(w io.Writer, p Post) error {
+	return r.execute(w, "layout.gohtml", newPostViewModel(p, r.Options))
+}
+
+func (r *PostRenderer) RenderIndex(w io.Writer, posts []Post) error {
+	return r.execute(w, "index.gohtml", r.indexPosts(posts))
+}
+
+// RenderIndexContext renders the same index as RenderIndex one post at a
+// time, giving up with the context's error as soon as ctx is done.
+func (r *PostRenderer) RenderIndexContext(ctx context.Context, w io.Writer, posts []Post) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := r.templ.ExecuteTemplate(w, "index-open", nil); err != nil {
+		return err
+	}
+	for _, p := range r.indexPosts(posts) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := r.templ.ExecuteTemplate(w, "index-item", p); err != nil {
+			return err
+		}
+	}
+	return r.templ.ExecuteTemplate(w, "index-close", nil)
+}
+
+// RenderIndexPage renders the given 1-based page of the index, linking to
+// the neighbouring pages. Pages past either end render an empty list.
+func (r *PostRenderer) RenderIndexPage(w io.Writer, posts []Post, page, perPage int) error {
+	if perPage <= 0 {
+		return fmt.Errorf("%w: got %d", ErrInvalidPerPage, perPage)
+	}
+
+	posts = r.indexPosts(posts)
+	pageCount := (len(posts) + perPage - 1) / perPage
+
+	data := struct {
+		Posts      []Post
+		Prev, Next int
+	}{Posts: []Post{}}
+	if page >= 1 && page <= pageCount {
+		start := (page - 1) * perPage
+		end := min(start+perPage, len(posts))
+		data.Posts = posts[start:end]
+		if page > 1 {
+			data.Prev = page - 1
+		}
+		if page < pageCount {
+			data.Next = page + 1
+		}
+	}
+
+	return r.execute(w, "page.gohtml", data)
+}
+
+func (r *PostRenderer) execute(w io.Writer, name string, data any) error {
+	if !r.Pretty && !r.Minify {
+		return r.templ.ExecuteTemplate(w, name, data)
+	}
+
+	var buf bytes.Buffer
+	if err := r.templ.ExecuteTemplate(&buf, name, data); err != nil {
+		return err
+	}
+	var formatted string
+	if r.Pretty {
+		formatted = indentHTML(buf.String()) + "\n"
+	} else {
+		formatted = minifyHTML(buf.String())
+	}
+	_, err := io.WriteString(w, formatted)
+	return err
+}
+
+// indexPosts returns a newest-first copy of posts, without drafts if the
+// renderer excludes them.
+func (r *PostRenderer) indexPosts(posts []Post) []Post {
+	sorted := slices.Clone(posts)
+	if r.ExcludeDrafts {
+		sorted = PublishedPosts(sorted)
+	}
+	SortPostsByDate(sorted)
+	return sorted
+}
+
+func (r *PostRenderer) RenderTagPage(w io.Writer, tag string, posts []Post) error {
+	return r.execute(w, "tag.gohtml", struct {
+		Tag   string
+		Posts []Post
+	}{tag, PostsWithTag(posts, tag)})
+}
+
+// Render parses the templates on every call. Prefer a PostRenderer when
+// rendering more than a handful of posts.
 func Render(w io.Writer, p Post) error {
-	templ, err := template.ParseFS(postTemplates, "templates/*.gohtml")
+	renderer, err := NewPostRenderer()
+	if err != nil {
+		return err
+	}
+	return renderer.Render(w, p)
+}
+
+func RenderTee(p Post, writers ...io.Writer) error {
+	renderer, err := NewPostRenderer()
+	if err != nil {
+		return err
+	}
+	return renderer.RenderTee(p, writers...)
+}
+
+func RenderPage(w io.Writer, p Post) error {
+	renderer, err := NewPostRenderer()
+	if err != nil {
+		return err
+	}
+	return renderer.RenderPage(w, p)
+}
+
+func RenderIndex(w io.Writer, posts []Post) error {
+	renderer, err := NewPostRenderer()
+	if err != nil {
+		return err
+	}
+	return renderer.RenderIndex(w, posts)
+}
+
+func RenderIndexContext(ctx context.Context, w io.Writer, posts []Post) error {
+	renderer, err := NewPostRenderer()
 	if err != nil {
 		return err
 	}
+	return renderer.RenderIndexContext(ctx, w, posts)
+}
 
-	if err := templ.Execute(w, p); err != nil {
+func RenderIndexPage(w io.Writer, posts []Post, page, perPage int) error {
+	renderer, err := NewPostRenderer()
+	if err != nil {
 		return err
 	}
+	return renderer.RenderIndexPage(w, posts, page, perPage)
+}
 
-	return nil
+func RenderTagPage(w io.Writer, tag string, posts []Post) error {
+	renderer, err := NewPostRenderer()
+	if err != nil {
+		return err
+	}
+	return renderer.RenderTagPage(w, tag, posts)
 }
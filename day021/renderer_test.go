@@ -2,7 +2,16 @@ package blogrenderer_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	blogrenderer "day021"
 )
@@ -27,10 +36,694 @@ func TestRender(t *testing.T) {
 
 <p>This is a description</p>
 
-Tags: <ul><li>go</li><li>tdd</li></ul>`
+<p>1 min read</p>
+
+Tags: <ul><li>go</li><li>tdd</li></ul>
+
+<p>This is a post</p>`
 
 		if got != want {
 			t.Errorf("got '%#v' want '%#v'", got, want)
 		}
 	})
+
+	t.Run("it pluralizes the tag label", func(t *testing.T) {
+		tagTests := []struct {
+			name string
+			tags []string
+			want string
+		}{
+			{"one tag", []string{"go"}, "Tag: <ul><li>go</li></ul>"},
+			{"many tags", []string{"go", "tdd", "html"}, "Tags: <ul><li>go</li><li>tdd</li><li>html</li></ul>"},
+		}
+
+		for _, tt := range tagTests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := bytes.Buffer{}
+				post := aPost
+				post.Tags = tt.tags
+
+				if err := blogrenderer.Render(&buf, post); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := buf.String(); !strings.Contains(got, tt.want) {
+					t.Errorf("got %q, want it to contain %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("it omits the tag section when there are no tags", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		post := aPost
+		post.Tags = nil
+
+		if err := blogrenderer.Render(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<h1>hello world</h1>
+
+<p>This is a description</p>
+
+<p>1 min read</p>
+
+<p>This is a post</p>`
+
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("a PostRenderer renders the same HTML as Render", func(t *testing.T) {
+		renderer, err := blogrenderer.NewPostRenderer()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, want := bytes.Buffer{}, bytes.Buffer{}
+		if err := renderer.Render(&got, aPost); err != nil {
+			t.Fatal(err)
+		}
+		if err := blogrenderer.Render(&want, aPost); err != nil {
+			t.Fatal(err)
+		}
+
+		if got.String() != want.String() {
+			t.Errorf("got %q want %q", got.String(), want.String())
+		}
+	})
+
+	t.Run("it escapes HTML in the title", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		post := aPost
+		post.Title = "<script>alert(1)</script>"
+
+		if err := blogrenderer.Render(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		if strings.Contains(got, "<script>") {
+			t.Errorf("title was not escaped: %q", got)
+		}
+		if want := "<h1>&lt;script&gt;alert(1)&lt;/script&gt;</h1>"; !strings.Contains(got, want) {
+			t.Errorf("got %q, want it to contain %q", got, want)
+		}
+	})
+
+	t.Run("it escapes special characters in tags", func(t *testing.T) {
+		post := aPost
+		post.Tags = []string{`c++ & "go"`, "<b>bold</b>"}
+
+		for _, style := range []blogrenderer.TagStyle{blogrenderer.TagList, blogrenderer.TagHashtags} {
+			renderer, err := blogrenderer.NewPostRenderer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			renderer.Options.TagStyle = style
+
+			buf := bytes.Buffer{}
+			if err := renderer.Render(&buf, post); err != nil {
+				t.Fatal(err)
+			}
+
+			got := buf.String()
+			if strings.Contains(got, `"go"`) || strings.Contains(got, "<b>") || strings.Contains(got, "& ") {
+				t.Errorf("tags were not escaped: %q", got)
+			}
+			if want := "c&#43;&#43; &amp; &#34;go&#34;"; !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("it adds CSS classes from the options", func(t *testing.T) {
+		renderer, err := blogrenderer.NewPostRenderer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		renderer.Options = blogrenderer.RenderOptions{
+			TitleClass:       "post-title",
+			DescriptionClass: "post-description",
+			TagsClass:        "tag-list",
+		}
+
+		buf := bytes.Buffer{}
+		if err := renderer.Render(&buf, aPost); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		for _, want := range []string{
+			`<h1 class="post-title">hello world</h1>`,
+			`<p class="post-description">This is a description</p>`,
+			`<ul class="tag-list"><li>go</li>`,
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %q", got, want)
+			}
+		}
+		if strings.Count(got, "class=") != 3 {
+			t.Errorf("expected classes only on the three configured elements, got %q", got)
+		}
+	})
+
+	t.Run("it can render tags as hashtags", func(t *testing.T) {
+		renderer, err := blogrenderer.NewPostRenderer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		renderer.Options.TagStyle = blogrenderer.TagHashtags
+
+		buf := bytes.Buffer{}
+		if err := renderer.Render(&buf, aPost); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<h1>hello world</h1>
+
+<p>This is a description</p>
+
+<p>1 min read</p>
+
+<p>#go #tdd</p>
+
+<p>This is a post</p>`
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("it streams output to the writer", func(t *testing.T) {
+		w := &countingWriter{}
+
+		if err := blogrenderer.Render(w, aPost); err != nil {
+			t.Fatal(err)
+		}
+
+		if w.writes < 2 {
+			t.Errorf("got %d writes, want the output to be written incrementally", w.writes)
+		}
+	})
+
+	t.Run("it returns errors from the writer", func(t *testing.T) {
+		err := blogrenderer.Render(failingWriter{}, aPost)
+
+		if !errors.Is(err, errWriteFailed) {
+			t.Errorf("got error %v want %v", err, errWriteFailed)
+		}
+	})
+
+	t.Run("it tees identical output to every writer", func(t *testing.T) {
+		first, second := bytes.Buffer{}, bytes.Buffer{}
+
+		if err := blogrenderer.RenderTee(aPost, &first, &second); err != nil {
+			t.Fatal(err)
+		}
+
+		if first.Len() == 0 || first.String() != second.String() {
+			t.Errorf("got %q and %q, want identical non-empty output", first.String(), second.String())
+		}
+
+		single := bytes.Buffer{}
+		if err := blogrenderer.Render(&single, aPost); err != nil {
+			t.Fatal(err)
+		}
+		if first.String() != single.String() {
+			t.Errorf("got %q want %q", first.String(), single.String())
+		}
+	})
+
+	t.Run("it returns errors from any teed writer", func(t *testing.T) {
+		err := blogrenderer.RenderTee(aPost, &bytes.Buffer{}, failingWriter{})
+		if !errors.Is(err, errWriteFailed) {
+			t.Errorf("got error %v want %v", err, errWriteFailed)
+		}
+	})
+
+	t.Run("pretty and minified output differ only in whitespace", func(t *testing.T) {
+		render := func(t *testing.T, pretty, minify bool) string {
+			t.Helper()
+			renderer, err := blogrenderer.NewPostRenderer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			renderer.Pretty, renderer.Minify = pretty, minify
+
+			buf := bytes.Buffer{}
+			if err := renderer.Render(&buf, aPost); err != nil {
+				t.Fatal(err)
+			}
+			return buf.String()
+		}
+
+		plain := render(t, false, false)
+		pretty := render(t, true, false)
+		minified := render(t, false, true)
+
+		if pretty == plain {
+			t.Fatalf("expected pretty output to differ, got %q", pretty)
+		}
+		if !strings.Contains(pretty, "<ul>\n  <li>go</li>\n  <li>tdd</li>\n</ul>") {
+			t.Errorf("expected the tag list to be indented, got %q", pretty)
+		}
+
+		want := "<h1>hello world</h1><p>This is a description</p><p>1 min read</p>Tags:<ul><li>go</li><li>tdd</li></ul><p>This is a post</p>"
+		if minified != want {
+			t.Errorf("got minified %q want %q", minified, want)
+		}
+
+		for _, got := range []string{pretty, minified} {
+			if withoutSpace(got) != withoutSpace(plain) {
+				t.Errorf("output %q has different structure from %q", got, plain)
+			}
+		}
+		if both := render(t, true, true); both != pretty {
+			t.Errorf("expected Pretty to win over Minify, got %q", both)
+		}
+	})
+
+	t.Run("it renders the body as markdown", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		post := aPost
+		post.Body = "Some **bold** text and a [link](https://go.dev)."
+
+		if err := blogrenderer.Render(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		for _, want := range []string{"<strong>bold</strong>", `<a href="https://go.dev">link</a>`} {
+			if !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("it shows the estimated reading time", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		post := aPost
+		post.Body = strings.Repeat("word ", 450)
+
+		if err := blogrenderer.Render(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := buf.String(); !strings.Contains(got, "<p>3 min read</p>") {
+			t.Errorf("got %q, want it to contain the reading time", got)
+		}
+	})
+
+	t.Run("it renders a complete HTML document", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		if err := blogrenderer.RenderPage(&buf, aPost); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		if !strings.HasPrefix(got, "<!DOCTYPE html>") {
+			t.Errorf("got %q, want it to start with a doctype", got)
+		}
+		for _, want := range []string{
+			"<title>hello world</title>",
+			`<meta name="description" content="This is a description">`,
+			"<h1>hello world</h1>",
+			"</html>",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("it shows when a page was last updated", func(t *testing.T) {
+		published := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+		const lastUpdated = "<p>Last updated: "
+
+		updatedTests := []struct {
+			name    string
+			updated time.Time
+			want    string
+		}{
+			{"updated after publishing", time.Date(2024, 3, 2, 15, 0, 0, 0, time.UTC), lastUpdated + "2024-03-02</p>"},
+			{"never updated", time.Time{}, ""},
+			{"updated when published", published, ""},
+		}
+
+		for _, tt := range updatedTests {
+			t.Run(tt.name, func(t *testing.T) {
+				post := aPost
+				post.Date = published
+				post.Updated = tt.updated
+
+				buf := bytes.Buffer{}
+				if err := blogrenderer.RenderPage(&buf, post); err != nil {
+					t.Fatal(err)
+				}
+
+				got := buf.String()
+				if tt.want == "" && strings.Contains(got, lastUpdated) {
+					t.Errorf("got %q, want no last updated date", got)
+				}
+				if tt.want != "" && !strings.Contains(got, tt.want) {
+					t.Errorf("got %q, want it to contain %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("it renders an index of posts", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		posts := []blogrenderer.Post{{Title: "Hello, World!"}, {Title: "Hello World 2"}}
+
+		if err := blogrenderer.RenderIndex(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<ol><li><a href="/post/hello-world">Hello, World!</a></li><li><a href="/post/hello-world-2">Hello World 2</a></li></ol>`
+
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("it lists the newest posts first", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		posts := []blogrenderer.Post{
+			{Title: "Old", Date: time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)},
+			{Title: "New", Date: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		}
+
+		if err := blogrenderer.RenderIndex(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<ol><li><a href="/post/new">New</a></li><li><a href="/post/old">Old</a></li></ol>`
+
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+		if posts[0].Title != "Old" {
+			t.Errorf("RenderIndex should not reorder the caller's slice")
+		}
+	})
+
+	t.Run("it can leave drafts out of the index", func(t *testing.T) {
+		posts := []blogrenderer.Post{{Title: "Published"}, {Title: "Work in progress", Draft: true}}
+
+		renderer, err := blogrenderer.NewPostRenderer()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		if err := renderer.RenderIndex(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "Work in progress") {
+			t.Errorf("drafts should be listed by default, got %q", buf.String())
+		}
+
+		renderer.ExcludeDrafts = true
+		buf.Reset()
+		if err := renderer.RenderIndex(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<ol><li><a href="/post/published">Published</a></li></ol>`
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("it renders the same index when given a context", func(t *testing.T) {
+		posts := []blogrenderer.Post{{Title: "Hello, World!"}, {Title: "Hello World 2"}}
+
+		got, want := bytes.Buffer{}, bytes.Buffer{}
+		if err := blogrenderer.RenderIndexContext(context.Background(), &got, posts); err != nil {
+			t.Fatal(err)
+		}
+		if err := blogrenderer.RenderIndex(&want, posts); err != nil {
+			t.Fatal(err)
+		}
+
+		if got.String() != want.String() {
+			t.Errorf("got %q want %q", got.String(), want.String())
+		}
+	})
+
+	t.Run("it stops rendering the index when the context is cancelled", func(t *testing.T) {
+		posts := []blogrenderer.Post{{Title: "One"}, {Title: "Two"}, {Title: "Three"}}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		buf := bytes.Buffer{}
+		w := writerFunc(func(p []byte) (int, error) {
+			if bytes.Contains(p, []byte("One")) {
+				cancel()
+			}
+			return buf.Write(p)
+		})
+
+		err := blogrenderer.RenderIndexContext(ctx, w, posts)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %v want %v", err, context.Canceled)
+		}
+		if strings.Contains(buf.String(), "Two") {
+			t.Errorf("rendering should have stopped after the first post, got %q", buf.String())
+		}
+	})
+
+	t.Run("it escapes titles in the index", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		posts := []blogrenderer.Post{{Title: "Fish & <Chips>"}}
+
+		if err := blogrenderer.RenderIndex(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		if strings.Contains(got, "<Chips>") || !strings.Contains(got, "Fish &amp; &lt;Chips&gt;") {
+			t.Errorf("title was not escaped: %q", got)
+		}
+	})
+}
+
+func TestNewPostRendererFromFile(t *testing.T) {
+	writeTemplate := func(t *testing.T, src string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "custom.gohtml")
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	post := blogrenderer.Post{Title: "hello world", Body: "Hi"}
+
+	t.Run("it renders posts with the custom template", func(t *testing.T) {
+		path := writeTemplate(t, `{{define "post"}}<article>{{.Title}} ({{slug .Post}})</article>{{end}}`)
+
+		renderer, err := blogrenderer.NewPostRendererFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		if err := renderer.Render(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "<article>hello world (hello-world)</article>"; got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+
+		buf.Reset()
+		if err := renderer.RenderPage(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "<body>\n<article>hello world (hello-world)</article>\n</body>") {
+			t.Errorf("expected the page to use the custom template, got %q", buf.String())
+		}
+	})
+
+	t.Run("it can use custom template functions", func(t *testing.T) {
+		path := writeTemplate(t, `{{define "post"}}<h1>{{upper .Title}}</h1>{{end}}`)
+
+		renderer, err := blogrenderer.NewPostRendererFromFile(path, blogrenderer.WithFuncs(template.FuncMap{
+			"upper": strings.ToUpper,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		if err := renderer.Render(&buf, post); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "<h1>HELLO WORLD</h1>"; got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("unknown functions are a parse error", func(t *testing.T) {
+		path := writeTemplate(t, `{{define "post"}}{{upper .Title}}{{end}}`)
+
+		if _, err := blogrenderer.NewPostRendererFromFile(path); err == nil {
+			t.Error("expected an error for an undefined function")
+		}
+	})
+
+	t.Run("it reports template syntax errors", func(t *testing.T) {
+		path := writeTemplate(t, `{{define "post"}}{{.Title}{{end}}`)
+
+		if _, err := blogrenderer.NewPostRendererFromFile(path); err == nil {
+			t.Error("expected an error for a malformed template")
+		}
+	})
+
+	t.Run("it requires a post block", func(t *testing.T) {
+		path := writeTemplate(t, `{{define "article"}}{{.Title}}{{end}}`)
+
+		_, err := blogrenderer.NewPostRendererFromFile(path)
+		if !errors.Is(err, blogrenderer.ErrMissingTemplate) {
+			t.Errorf("got error %v want %v", err, blogrenderer.ErrMissingTemplate)
+		}
+	})
+
+	t.Run("both index renderers use the overridden list wrapper", func(t *testing.T) {
+		path := writeTemplate(t, `{{define "post"}}{{.Title}}{{end}}{{define "index-open"}}<ul class="posts">{{end}}{{define "index-close"}}</ul>{{end}}`)
+
+		renderer, err := blogrenderer.NewPostRendererFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		posts := []blogrenderer.Post{{Title: "Hello World"}}
+		want := `<ul class="posts"><li><a href="/post/hello-world">Hello World</a></li></ul>`
+		buf := bytes.Buffer{}
+		if err := renderer.RenderIndex(&buf, posts); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("RenderIndex got %q want %q", got, want)
+		}
+
+		buf.Reset()
+		if err := renderer.RenderIndexContext(context.Background(), &buf, posts); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("RenderIndexContext got %q want %q", got, want)
+		}
+	})
+
+	t.Run("it reports missing files", func(t *testing.T) {
+		_, err := blogrenderer.NewPostRendererFromFile(filepath.Join(t.TempDir(), "missing.gohtml"))
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("got error %v want %v", err, fs.ErrNotExist)
+		}
+	})
+}
+
+func TestWithFuncs(t *testing.T) {
+	t.Run("custom functions take precedence over built-in ones", func(t *testing.T) {
+		renderer, err := blogrenderer.NewPostRenderer(blogrenderer.WithFuncs(template.FuncMap{
+			"slug": func(p blogrenderer.Post) string { return "custom" },
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		if err := renderer.RenderIndex(&buf, []blogrenderer.Post{{Title: "Hello World"}}); err != nil {
+			t.Fatal(err)
+		}
+		if want := `<a href="/post/custom">Hello World</a>`; !strings.Contains(buf.String(), want) {
+			t.Errorf("got %q, want it to contain %q", buf.String(), want)
+		}
+	})
+
+	t.Run("later options win", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.gohtml")
+		if err := os.WriteFile(path, []byte(`{{define "post"}}{{shout .Title}}{{end}}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		renderer, err := blogrenderer.NewPostRendererFromFile(path,
+			blogrenderer.WithFuncs(template.FuncMap{"shout": strings.ToUpper}),
+			blogrenderer.WithFuncs(template.FuncMap{"shout": func(s string) string { return s + "!" }}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		if err := renderer.Render(&buf, blogrenderer.Post{Title: "hi"}); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "hi!"; got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+}
+
+func withoutSpace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+type countingWriter struct {
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return len(p), nil
+}
+
+var errWriteFailed = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWriteFailed
+}
+
+func BenchmarkRender(b *testing.B) {
+	aPost := blogrenderer.Post{
+		Title:       "hello world",
+		Body:        "This is a post",
+		Description: "This is a description",
+		Tags:        []string{"go", "tdd"},
+	}
+
+	b.Run("parsing templates on every call", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			blogrenderer.Render(io.Discard, aPost)
+		}
+	})
+
+	b.Run("with a cached PostRenderer", func(b *testing.B) {
+		renderer, err := blogrenderer.NewPostRenderer()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			renderer.Render(io.Discard, aPost)
+		}
+	})
 }
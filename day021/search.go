@@ -0,0 +1,39 @@
+package blogrenderer
+
+import (
+	"slices"
+	"strings"
+)
+
+// SearchPosts returns the posts whose title, description or body contain
+// query, ignoring case. Title matches come first, then description matches,
+// then body matches, each group keeping the original order.
+func SearchPosts(posts []Post, query string) []Post {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return posts
+	}
+
+	type match struct {
+		post Post
+		rank int
+	}
+	var matches []match
+	for _, p := range posts {
+		for rank, field := range []string{p.Title, p.Description, p.Body} {
+			if strings.Contains(strings.ToLower(field), query) {
+				matches = append(matches, match{p, rank})
+				break
+			}
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return a.rank - b.rank
+	})
+
+	found := make([]Post, len(matches))
+	for i, m := range matches {
+		found[i] = m.post
+	}
+	return found
+}
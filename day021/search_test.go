@@ -0,0 +1,46 @@
+package blogrenderer_test
+
+import (
+	"testing"
+
+	blogrenderer "day021"
+)
+
+func TestSearchPosts(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "Cooking pasta", Description: "Dinner", Body: "Boil water."},
+		{Title: "Templates", Description: "Rendering", Body: "We write Go code."},
+		{Title: "Learning Go", Description: "An intro", Body: "Start here."},
+		{Title: "Concurrency", Description: "Go routines", Body: "Channels."},
+	}
+
+	t.Run("title matches rank before body matches", func(t *testing.T) {
+		got := blogrenderer.SearchPosts(posts, "go")
+
+		assertTitles(t, got, "Learning Go", "Concurrency", "Templates")
+	})
+
+	t.Run("title only", func(t *testing.T) {
+		got := blogrenderer.SearchPosts(posts, "PASTA")
+
+		assertTitles(t, got, "Cooking pasta")
+	})
+
+	t.Run("body only", func(t *testing.T) {
+		got := blogrenderer.SearchPosts(posts, "channels")
+
+		assertTitles(t, got, "Concurrency")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		got := blogrenderer.SearchPosts(posts, "rust")
+
+		assertTitles(t, got)
+	})
+
+	t.Run("empty query returns every post", func(t *testing.T) {
+		got := blogrenderer.SearchPosts(posts, "  ")
+
+		assertTitles(t, got, "Cooking pasta", "Templates", "Learning Go", "Concurrency")
+	})
+}
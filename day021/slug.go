@@ -0,0 +1,79 @@
+package blogrenderer
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// transliterations maps common accented Latin letters to ASCII. Any other
+// non-ASCII character is dropped from slugs.
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'ß': "ss",
+}
+
+// Slug turns the post title into a lowercase, hyphen-separated string that's
+// safe to use in a URL, e.g. "Hello, World!" becomes "hello-world".
+func Slug(p Post) string {
+	return slugify(p.Title)
+}
+
+// postKey identifies p by its slug, or by its title when nothing in the title
+// survives slugify, so that posts titled in, say, Chinese don't all share the
+// empty slug.
+func postKey(p Post) string {
+	if slug := Slug(p); slug != "" {
+		return slug
+	}
+	return p.Title
+}
+
+// CanonicalURL joins the absolute URL base with p's "/post/<slug>" path. Any
+// trailing slashes on base are dropped first. A base with a query or fragment
+// is rejected, since the post path can't follow either.
+func CanonicalURL(base string, p Post) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidBaseURL, base)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.ForceQuery {
+		return "", fmt.Errorf("%w: %q has a query or fragment", ErrInvalidBaseURL, base)
+	}
+	return u.JoinPath("post", Slug(p)).String(), nil
+}
+
+func slugify(s string) string {
+	var slug strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		case transliterations[r] != "":
+			part = transliterations[r]
+		case r == ' ' || r == '-' || r == '_' || r == '\t':
+			pendingHyphen = slug.Len() > 0
+			continue
+		default:
+			continue
+		}
+		if pendingHyphen {
+			slug.WriteByte('-')
+			pendingHyphen = false
+		}
+		slug.WriteString(part)
+	}
+	return slug.String()
+}
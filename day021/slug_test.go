@@ -0,0 +1,70 @@
+package blogrenderer_test
+
+import (
+	"errors"
+	"testing"
+
+	blogrenderer "day021"
+)
+
+func TestSlug(t *testing.T) {
+	slugTests := []struct {
+		title string
+		want  string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"Hello World 2", "hello-world-2"},
+		{"multiple   spaces\tand tabs", "multiple-spaces-and-tabs"},
+		{"  - leading and trailing -  ", "leading-and-trailing"},
+		{"already-hyphenated -- title", "already-hyphenated-title"},
+		{"What's new in Go 1.21?", "whats-new-in-go-121"},
+		{"Crème brûlée", "creme-brulee"},
+		{"日本語 title", "title"},
+		{"", ""},
+	}
+
+	for _, tt := range slugTests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := blogrenderer.Slug(blogrenderer.Post{Title: tt.title})
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	post := blogrenderer.Post{Title: "Hello, World!"}
+
+	canonicalTests := []struct {
+		name string
+		base string
+		want string
+	}{
+		{"without a trailing slash", "https://example.com", "https://example.com/post/hello-world"},
+		{"with a trailing slash", "https://example.com/", "https://example.com/post/hello-world"},
+		{"with several trailing slashes", "https://example.com//", "https://example.com/post/hello-world"},
+		{"with a path", "https://example.com/blog/", "https://example.com/blog/post/hello-world"},
+	}
+
+	for _, tt := range canonicalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := blogrenderer.CanonicalURL(tt.base, post)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, base := range []string{"", "example.com", "/post", "https://", "://example.com", "https://example.com/?a=b", "https://example.com#top", "https://example.com/blog?"} {
+		t.Run("rejects "+base, func(t *testing.T) {
+			_, err := blogrenderer.CanonicalURL(base, post)
+			if !errors.Is(err, blogrenderer.ErrInvalidBaseURL) {
+				t.Errorf("got error %v want %v", err, blogrenderer.ErrInvalidBaseURL)
+			}
+		})
+	}
+}
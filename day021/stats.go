@@ -0,0 +1,71 @@
+package blogrenderer
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const wordsPerMinute = 200
+
+// ReadingTime estimates how long p takes to read at wordsPerMinute.
+func ReadingTime(p Post) time.Duration {
+	return ReadingTimeWPM(p, wordsPerMinute)
+}
+
+// ReadingTimeWPM estimates how long p takes to read at wpm words per minute,
+// rounded up to the nearest minute and never less than one minute. A wpm of
+// zero or less falls back to wordsPerMinute.
+func ReadingTimeWPM(p Post, wpm int) time.Duration {
+	if wpm <= 0 {
+		wpm = wordsPerMinute
+	}
+	words := WordCount(p)
+	minutes := (words + wpm - 1) / wpm
+	if minutes < 1 {
+		minutes = 1
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// WordCount counts the words in p's body once its Markdown syntax has been
+// stripped, so heading markers and link URLs don't count as words.
+func WordCount(p Post) int {
+	return len(strings.Fields(StripMarkdown(p.Body)))
+}
+
+// Stats summarises the body of a post.
+type Stats struct {
+	Words             int
+	Characters        int
+	AverageWordLength float64
+	Sentences         int
+}
+
+// PostStats counts the words, runes and sentences in p's body. Sentences end
+// at '.', '!' or '?', and a run of those marks ends just one sentence.
+func PostStats(p Post) Stats {
+	words := strings.Fields(p.Body)
+	stats := Stats{
+		Words:      len(words),
+		Characters: utf8.RuneCountInString(p.Body),
+	}
+
+	if len(words) > 0 {
+		letters := 0
+		for _, word := range words {
+			letters += utf8.RuneCountInString(word)
+		}
+		stats.AverageWordLength = float64(letters) / float64(len(words))
+	}
+
+	sentences := strings.FieldsFunc(p.Body, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?'
+	})
+	for _, sentence := range sentences {
+		if strings.TrimSpace(sentence) != "" {
+			stats.Sentences++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,120 @@
+package blogrenderer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	blogrenderer "day021"
+)
+
+func TestReadingTime(t *testing.T) {
+	readingTimeTests := []struct {
+		name  string
+		words int
+		want  time.Duration
+	}{
+		{"empty post floors to a minute", 0, time.Minute},
+		{"short post floors to a minute", 20, time.Minute},
+		{"exactly one minute", 200, time.Minute},
+		{"rounds up", 201, 2 * time.Minute},
+		{"long post", 550, 3 * time.Minute},
+	}
+
+	for _, tt := range readingTimeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			post := blogrenderer.Post{Body: strings.Repeat("word ", tt.words)}
+
+			got := blogrenderer.ReadingTime(post)
+			if got != tt.want {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadingTimeWPM(t *testing.T) {
+	post := blogrenderer.Post{Body: strings.Repeat("word ", 1000)}
+
+	t.Run("slower readers take longer", func(t *testing.T) {
+		slow := blogrenderer.ReadingTimeWPM(post, 100)
+		fast := blogrenderer.ReadingTimeWPM(post, 400)
+
+		if slow != 10*time.Minute {
+			t.Errorf("got %v at 100 wpm want %v", slow, 10*time.Minute)
+		}
+		if fast != 3*time.Minute {
+			t.Errorf("got %v at 400 wpm want %v", fast, 3*time.Minute)
+		}
+	})
+
+	t.Run("non-positive speeds fall back to 200 wpm", func(t *testing.T) {
+		for _, wpm := range []int{0, -50} {
+			got := blogrenderer.ReadingTimeWPM(post, wpm)
+			if want := blogrenderer.ReadingTime(post); got != want {
+				t.Errorf("got %v at %d wpm want %v", got, wpm, want)
+			}
+		}
+	})
+}
+
+func TestWordCount(t *testing.T) {
+	wordCountTests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"empty body", "", 0},
+		{"whitespace only", " \t\n  ", 0},
+		{"single spaces", "one two three", 3},
+		{"mixed whitespace", "one  two\tthree\n\nfour", 4},
+		{"punctuation stays attached", "Hello, world! It's -- fine.", 5},
+		{"markdown syntax isn't counted", "# Title\n\nSee [the docs](https://go.dev) and `x`", 6},
+	}
+
+	for _, tt := range wordCountTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blogrenderer.WordCount(blogrenderer.Post{Body: tt.body})
+			if got != tt.want {
+				t.Errorf("got %d want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostStats(t *testing.T) {
+	t.Run("a normal paragraph", func(t *testing.T) {
+		post := blogrenderer.Post{Body: "Go is fun. Is it fast? Yes!"}
+
+		got := blogrenderer.PostStats(post)
+		want := blogrenderer.Stats{
+			Words:             7,
+			Characters:        27,
+			AverageWordLength: 21.0 / 7,
+			Sentences:         3,
+		}
+		if got != want {
+			t.Errorf("got %+v want %+v", got, want)
+		}
+	})
+
+	t.Run("runes are counted rather than bytes", func(t *testing.T) {
+		got := blogrenderer.PostStats(blogrenderer.Post{Body: "Café ünïcode..."})
+		want := blogrenderer.Stats{
+			Words:             2,
+			Characters:        15,
+			AverageWordLength: 7,
+			Sentences:         1,
+		}
+		if got != want {
+			t.Errorf("got %+v want %+v", got, want)
+		}
+	})
+
+	t.Run("an empty body has zeroed stats", func(t *testing.T) {
+		got := blogrenderer.PostStats(blogrenderer.Post{})
+		if want := (blogrenderer.Stats{}); got != want {
+			t.Errorf("got %+v want %+v", got, want)
+		}
+	})
+}
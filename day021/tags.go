@@ -0,0 +1,135 @@
+package blogrenderer
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// PostsWithTag returns the posts tagged with tag, compared case-insensitively,
+// keeping only the first post seen with any given title.
+func PostsWithTag(posts []Post, tag string) []Post {
+	matching := []Post{}
+	seen := map[string]bool{}
+	for _, p := range posts {
+		if seen[p.Title] || !p.hasTag(tag) {
+			continue
+		}
+		seen[p.Title] = true
+		matching = append(matching, p)
+	}
+	return matching
+}
+
+func (p Post) hasTag(tag string) bool {
+	for _, t := range p.Tags {
+		if strings.EqualFold(strings.TrimSpace(t), strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTags trims and lower-cases tags, dropping empty and duplicate ones
+// while keeping the order in which they were first seen.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// AllTags returns every tag used across posts, normalized and sorted.
+func AllTags(posts []Post) []string {
+	var tags []string
+	for _, p := range posts {
+		tags = append(tags, p.Tags...)
+	}
+	all := NormalizeTags(tags)
+	if all == nil {
+		return []string{}
+	}
+	slices.Sort(all)
+	return all
+}
+
+// TagWeight is how many posts use a tag.
+type TagWeight struct {
+	Tag   string
+	Count int
+}
+
+// TagCloud counts the posts using each normalized tag, most used first and
+// then alphabetically.
+func TagCloud(posts []Post) []TagWeight {
+	counts := map[string]int{}
+	for _, p := range posts {
+		for _, tag := range NormalizeTags(p.Tags) {
+			counts[tag]++
+		}
+	}
+
+	cloud := []TagWeight{}
+	for tag, count := range counts {
+		cloud = append(cloud, TagWeight{tag, count})
+	}
+	slices.SortFunc(cloud, func(a, b TagWeight) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return cmp.Compare(a.Tag, b.Tag)
+	})
+	return cloud
+}
+
+// RelatedPosts ranks the posts in all by how many tags they share with
+// target, most first and then by title, and returns up to limit of them.
+// target itself, matched by slug or by title when the slug is empty, and posts
+// sharing no tags are left out.
+func RelatedPosts(target Post, all []Post, limit int) []Post {
+	type scored struct {
+		post   Post
+		shared int
+	}
+
+	targetTags := NormalizeTags(target.Tags)
+	targetKey := postKey(target)
+	var candidates []scored
+	for _, p := range all {
+		if postKey(p) == targetKey {
+			continue
+		}
+		shared := 0
+		for _, tag := range NormalizeTags(p.Tags) {
+			if slices.Contains(targetTags, tag) {
+				shared++
+			}
+		}
+		if shared > 0 {
+			candidates = append(candidates, scored{p, shared})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b scored) int {
+		if a.shared != b.shared {
+			return b.shared - a.shared
+		}
+		return cmp.Compare(a.post.Title, b.post.Title)
+	})
+
+	related := []Post{}
+	for _, c := range candidates {
+		if len(related) >= limit {
+			break
+		}
+		related = append(related, c.post)
+	}
+	return related
+}
@@ -0,0 +1,168 @@
+package blogrenderer_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	blogrenderer "day021"
+)
+
+func TestRenderTagPage(t *testing.T) {
+	posts := []blogrenderer.Post{
+		{Title: "Go Basics", Tags: []string{"Go", "beginner"}},
+		{Title: "Rust Basics", Tags: []string{"rust", "beginner"}},
+		{Title: "Go Concurrency", Tags: []string{"go"}},
+		{Title: "Go Basics", Tags: []string{"go"}},
+	}
+
+	t.Run("it lists posts sharing the tag, ignoring case and duplicates", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		if err := blogrenderer.RenderTagPage(&buf, "GO", posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<h1>Posts tagged GO</h1><ol><li><a href="/post/go-basics">Go Basics</a></li><li><a href="/post/go-concurrency">Go Concurrency</a></li></ol>`
+
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("it renders an empty list when no post has the tag", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		if err := blogrenderer.RenderTagPage(&buf, "python", posts); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		want := `<h1>Posts tagged python</h1><ol></ol>`
+
+		if got != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+}
+
+func TestNormalizeTags(t *testing.T) {
+	normalizeTests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"already clean", []string{"go", "tdd"}, []string{"go", "tdd"}},
+		{"duplicates", []string{"go", "tdd", "go"}, []string{"go", "tdd"}},
+		{"mixed case", []string{"Go", "TDD", "gO"}, []string{"go", "tdd"}},
+		{"surrounding whitespace", []string{"  go ", "\ttdd"}, []string{"go", "tdd"}},
+		{"empty tags", []string{"", "  ", "go"}, []string{"go"}},
+		{"no tags", nil, nil},
+	}
+
+	for _, tt := range normalizeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blogrenderer.NormalizeTags(tt.tags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllTags(t *testing.T) {
+	t.Run("unique tags across posts, sorted", func(t *testing.T) {
+		posts := []blogrenderer.Post{
+			{Tags: []string{"tdd", "go"}},
+			{Tags: []string{"Go", "rust"}},
+			{Tags: []string{"borrow-checker", "RUST"}},
+			{},
+		}
+
+		got := blogrenderer.AllTags(posts)
+		want := []string{"borrow-checker", "go", "rust", "tdd"}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q want %q", got, want)
+		}
+	})
+
+	t.Run("no posts", func(t *testing.T) {
+		got := blogrenderer.AllTags(nil)
+
+		if got == nil || len(got) != 0 {
+			t.Errorf("got %#v want an empty slice", got)
+		}
+	})
+}
+
+func TestTagCloud(t *testing.T) {
+	t.Run("most used tags first, then alphabetical", func(t *testing.T) {
+		posts := []blogrenderer.Post{
+			{Tags: []string{"go", "tdd"}},
+			{Tags: []string{"Go", "rust"}},
+			{Tags: []string{"GO", "Rust", "go"}},
+			{Tags: []string{"tdd", "zig", "arrays"}},
+			{},
+		}
+
+		got := blogrenderer.TagCloud(posts)
+		want := []blogrenderer.TagWeight{
+			{"go", 3},
+			{"rust", 2},
+			{"tdd", 2},
+			{"arrays", 1},
+			{"zig", 1},
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	})
+
+	t.Run("no posts", func(t *testing.T) {
+		got := blogrenderer.TagCloud(nil)
+
+		if got == nil || len(got) != 0 {
+			t.Errorf("got %#v want an empty slice", got)
+		}
+	})
+}
+
+func TestRelatedPosts(t *testing.T) {
+	target := blogrenderer.Post{Title: "Testing in Go", Tags: []string{"go", "tdd", "testing"}}
+	all := []blogrenderer.Post{
+		target,
+		{Title: "Rust ownership", Tags: []string{"rust"}},
+		{Title: "Mocking in Go", Tags: []string{"Go", "TDD", "testing"}},
+		{Title: "Go generics", Tags: []string{"go"}},
+		{Title: "Benchmarks", Tags: []string{"go", "testing"}},
+		{Title: "A Go tour", Tags: []string{"go"}},
+	}
+
+	t.Run("ranks by shared tags, then title", func(t *testing.T) {
+		got := blogrenderer.RelatedPosts(target, all, 10)
+
+		assertTitles(t, got, "Mocking in Go", "Benchmarks", "A Go tour", "Go generics")
+	})
+
+	t.Run("respects the limit", func(t *testing.T) {
+		got := blogrenderer.RelatedPosts(target, all, 2)
+
+		assertTitles(t, got, "Mocking in Go", "Benchmarks")
+	})
+
+	t.Run("no related posts", func(t *testing.T) {
+		got := blogrenderer.RelatedPosts(blogrenderer.Post{Title: "Cooking", Tags: []string{"food"}}, all, 3)
+
+		assertTitles(t, got)
+	})
+
+	t.Run("posts without a slug are told apart by title", func(t *testing.T) {
+		target := blogrenderer.Post{Title: "你好", Tags: []string{"go"}}
+		all := []blogrenderer.Post{target, {Title: "世界", Tags: []string{"go"}}}
+
+		got := blogrenderer.RelatedPosts(target, all, 3)
+
+		assertTitles(t, got, "世界")
+	})
+}
@@ -0,0 +1,22 @@
+package blogrenderer
+
+import "strings"
+
+type TocEntry struct {
+	Text  string
+	Level int
+	ID    string
+}
+
+// TableOfContents lists the headings of the post body in order, with the
+// same ids renderMarkdown gives them.
+func TableOfContents(p Post) []TocEntry {
+	toc := []TocEntry{}
+	ids := headingIDs{}
+	for _, line := range strings.Split(p.Body, "\n") {
+		if level, text, ok := parseHeading(strings.TrimSpace(line)); ok {
+			toc = append(toc, TocEntry{Text: text, Level: level, ID: ids.next(text)})
+		}
+	}
+	return toc
+}
@@ -0,0 +1,44 @@
+package blogrenderer_test
+
+import (
+	"reflect"
+	"testing"
+
+	blogrenderer "day021"
+)
+
+func TestTableOfContents(t *testing.T) {
+	t.Run("headings in order with their levels", func(t *testing.T) {
+		post := blogrenderer.Post{Body: `Intro paragraph.
+
+## Getting Started
+Some text.
+
+### Installing
+### Configuring
+
+## Usage
+### Configuring`}
+
+		got := blogrenderer.TableOfContents(post)
+		want := []blogrenderer.TocEntry{
+			{Text: "Getting Started", Level: 2, ID: "getting-started"},
+			{Text: "Installing", Level: 3, ID: "installing"},
+			{Text: "Configuring", Level: 3, ID: "configuring"},
+			{Text: "Usage", Level: 2, ID: "usage"},
+			{Text: "Configuring", Level: 3, ID: "configuring-1"},
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v want %+v", got, want)
+		}
+	})
+
+	t.Run("no headings", func(t *testing.T) {
+		got := blogrenderer.TableOfContents(blogrenderer.Post{Body: "Just text"})
+
+		if len(got) != 0 {
+			t.Errorf("got %+v want no entries", got)
+		}
+	})
+}